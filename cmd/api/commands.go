@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/domain"
+)
+
+const usage = `Usage:
+  api                          Start the HTTP and gRPC servers
+  api migrate up               Apply all pending migrations
+  api migrate down [N]         Roll back the last N migrations (default 1)
+  api migrate status           Show the schema version and pending migrations
+  api migrate force VERSION    Set the schema version and clear the dirty flag
+  api seed [flags]             Load menu fixtures, skipping menus whose code exists
+  api config print [--format=yaml|toml]
+                               Show the merged configuration with secrets redacted
+
+Seed flags:
+  --fixture=NAME[,NAME...]     default, large or deep, loaded in order (default "default")
+  --size=N                     Number of menus in the large and deep fixtures
+  --depth=N                    Number of levels in the large and deep fixtures`
+
+// runCommand runs the subcommand named by the first command line argument
+func runCommand(cfg *config.Config, name string, args []string) error {
+	switch name {
+	case "migrate":
+		return runMigrate(cfg, args)
+	case "seed":
+		return runSeed(cfg, args)
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q\n\n%s", name, usage)
+}
+
+// menuLimits returns the tree limits configured for the menu service
+func menuLimits(cfg *config.Config) domain.MenuLimits {
+	return domain.MenuLimits{
+		MaxDepth:    cfg.Menu.MaxDepth,
+		MaxChildren: cfg.Menu.MaxChildren,
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"stk-technical-test-api/internal/config"
+)
+
+// runConfig implements the config subcommand. It runs before the
+// configuration is validated, so that an invalid one can still be inspected.
+func runConfig(cfg *config.Config, loadErr error, args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		return fmt.Errorf("usage: api config print [--format=yaml|toml]\n\n%s", usage)
+	}
+
+	flags := flag.NewFlagSet("config print", flag.ContinueOnError)
+	format := flags.String("format", "yaml", "output format: yaml or toml")
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	// Files or values that could not be read leave nothing to print
+	if cfg == nil {
+		return loadErr
+	}
+
+	sources := append([]string{"defaults"}, cfg.Files...)
+	fmt.Printf("# Merged from %s and the environment; secrets are redacted\n", strings.Join(sources, ", "))
+	if err := cfg.Redacted().Encode(os.Stdout, *format); err != nil {
+		return err
+	}
+	return loadErr
+}
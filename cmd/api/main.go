@@ -1,91 +1,575 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"stk-technical-test-api/internal/config"
 	"stk-technical-test-api/internal/database"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/event"
+	"stk-technical-test-api/internal/graph"
 	"stk-technical-test-api/internal/handler"
+	"stk-technical-test-api/internal/logging"
+	"stk-technical-test-api/internal/metrics"
+	"stk-technical-test-api/internal/middleware"
+	"stk-technical-test-api/internal/openapi"
+	"stk-technical-test-api/internal/ratelimit"
 	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/rpc"
 	"stk-technical-test-api/internal/service"
+	"stk-technical-test-api/internal/tracing"
+	"stk-technical-test-api/internal/worker"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/quic-go/quic-go/http3"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
+// @title STK Menu API
+// @version 1.0.0
+// @description RESTful API for hierarchical menu management
 func main() {
 	// Load configuration
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+
+	// Print the configuration even when it is invalid, to help fix it
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfig(cfg, err, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// Log structured records from here on, including the standard logger
+	if err := logging.Setup(cfg.Log); err != nil {
+		log.Fatal(err)
+	}
+
+	// Run a subcommand such as "migrate" instead of the servers
+	if len(os.Args) > 1 {
+		if err := runCommand(cfg, os.Args[1], os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := serve(cfg); err != nil {
+		slog.Error("Server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// serve runs the HTTP and gRPC servers until a shutdown signal or a server
+// failure. On the way out the servers drain in-flight requests, then the
+// background workers stop, then the database is closed.
+func serve(cfg *config.Config) error {
+	// Initialize tracing; pending spans are flushed after everything else
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Tracing shutdown error", "error", err)
+		}
+	}()
 
 	// Initialize database
-	db, err := database.NewDatabase(cfg.GetDSN(), cfg.App.Env == "development")
+	db, err := database.NewDatabase(cfg.Database, cfg.Log.SlowQueryThreshold)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			slog.Error("Database close error", "error", err)
+		}
+	}()
+
+	// Trace every query as a child of the request or service span
+	if cfg.Tracing.Enabled() {
+		if err := db.GetDB().Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics())); err != nil {
+			return fmt.Errorf("failed to enable query tracing: %w", err)
+		}
+	}
+
+	// Refuse to serve with a schema older than this build
+	migrator, err := newMigrator(cfg, db)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		return err
+	}
+	if err := prepareSchema(context.Background(), cfg, migrator); err != nil {
+		return fmt.Errorf("database schema check failed: %w", err)
 	}
-	defer db.Close()
 
 	// Initialize dependencies (Dependency Injection)
+	eventBroker := event.NewBroker(cfg.Events.BufferSize)
 	menuRepo := repository.NewMenuRepository(db.GetDB())
-	menuService := service.NewMenuService(menuRepo)
+
+	var appMetrics *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		sqlDB, err := db.GetDB().DB()
+		if err != nil {
+			return err
+		}
+		appMetrics = metrics.New()
+		appMetrics.RegisterDB(sqlDB, cfg.Database.Driver)
+		appMetrics.RegisterMenuCounts(menuRepo, cfg.Server.ReadinessTimeout)
+		menuRepo = metrics.NewMenuRepository(menuRepo, appMetrics)
+	}
+
+	menuService := service.NewMenuService(menuRepo, eventBroker, menuLimits(cfg))
+	if cfg.Tracing.Enabled() {
+		menuService = tracing.NewMenuService(menuService)
+	}
 	menuHandler := handler.NewMenuHandler(menuService)
+	templateRepo := repository.NewMenuTemplateRepository(db.GetDB())
+	templateHandler := handler.NewMenuTemplateHandler(service.NewMenuTemplateService(templateRepo, menuService, menuLimits(cfg)))
+	eventHandler := handler.NewEventHandler(eventBroker)
+	webhookRepo := repository.NewWebhookRepository(db.GetDB())
+	webhookService := service.NewWebhookService(webhookRepo, cfg.Webhook.AllowPrivateURLs)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+
+	schema, err := graph.NewSchema(menuService)
+	if err != nil {
+		return fmt.Errorf("failed to parse GraphQL schema: %w", err)
+	}
+	graphqlHandler := handler.NewGraphQLHandler(schema)
+	docsHandler := handler.NewDocsHandler(openapi.Spec)
+	healthHandler := handler.NewHealthHandler(cfg.Server.ReadinessTimeout,
+		handler.HealthCheck{Name: "database", Check: db.Ping},
+		handler.HealthCheck{Name: "migrations", Check: migrator.CheckCurrent},
+	)
+
+	// Start webhook delivery worker. It is stopped after the servers have
+	// drained and before the database is closed.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	defer func() {
+		stopWorkers()
+		workers.Wait()
+	}()
+
+	if cfg.Webhook.Enabled {
+		webhookWorker := worker.NewWebhookWorker(webhookRepo, nil, worker.WebhookWorkerConfig{
+			PollInterval:     cfg.Webhook.PollInterval,
+			BatchSize:        cfg.Webhook.BatchSize,
+			MaxAttempts:      cfg.Webhook.MaxAttempts,
+			BaseBackoff:      cfg.Webhook.BaseBackoff,
+			MaxBackoff:       cfg.Webhook.MaxBackoff,
+			Timeout:          cfg.Webhook.Timeout,
+			Retention:        cfg.Webhook.Retention,
+			AllowPrivateURLs: cfg.Webhook.AllowPrivateURLs,
+		})
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			webhookWorker.Run(workerCtx)
+		}()
+	}
+
+	var idempotencyRepo domain.IdempotencyRepository
+	if cfg.Idempotency.Enabled {
+		idempotencyRepo = repository.NewIdempotencyRepository(db.GetDB())
+		purger := worker.NewIdempotencyPurger(idempotencyRepo, min(cfg.Idempotency.TTL, time.Hour))
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			purger.Run(workerCtx)
+		}()
+	}
 
 	// Setup Gin router
-	router := setupRouter(menuHandler, cfg)
+	router, err := setupRouter(&handlers{
+		menu:        menuHandler,
+		template:    templateHandler,
+		event:       eventHandler,
+		webhook:     webhookHandler,
+		graphql:     graphqlHandler,
+		docs:        docsHandler,
+		health:      healthHandler,
+		metrics:     appMetrics,
+		idempotency: idempotencyRepo,
+	}, cfg)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := newTLSConfig(workerCtx, cfg.TLS, &workers)
+	if err != nil {
+		return err
+	}
+
+	// Serve HTTP/3 on the UDP port matching the API port and advertise it
+	// on the TCP responses
+	var httpHandler http.Handler = router
+	var h3Server *http3.Server
+	if cfg.TLS.HTTP3 {
+		h3Server = &http3.Server{
+			Handler:        router,
+			TLSConfig:      http3.ConfigureTLSConfig(tlsConfig),
+			MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+			IdleTimeout:    cfg.Server.IdleTimeout,
+		}
+		httpHandler = advertiseHTTP3(h3Server, router)
+	}
+
+	httpServer := &http.Server{
+		Addr:              ":" + cfg.Server.Port,
+		Handler:           httpHandler,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+	// Close event streams so long-lived SSE and gRPC watch requests finish
+	httpServer.RegisterOnShutdown(eventBroker.Close)
+
+	// Listen before serving so a busy port fails startup instead of shutdown
+	httpListener, err := net.Listen("tcp", httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for HTTP: %w", err)
+	}
+
+	var h3Conn net.PacketConn
+	if h3Server != nil {
+		h3Conn, err = net.ListenPacket("udp", httpServer.Addr)
+		if err != nil {
+			httpListener.Close()
+			return fmt.Errorf("failed to listen for HTTP/3: %w", err)
+		}
+		defer h3Conn.Close()
+	}
+
+	var (
+		grpcServer   *grpc.Server
+		grpcListener net.Listener
+	)
+	if cfg.GRPC.Enabled {
+		grpcListener, err = net.Listen("tcp", ":"+cfg.GRPC.Port)
+		if err != nil {
+			httpListener.Close()
+			return fmt.Errorf("failed to listen for gRPC: %w", err)
+		}
+		opts := []grpc.ServerOption{grpc.UnaryInterceptor(rpc.TimeoutInterceptor(cfg.Server.RequestTimeout))}
+		if tlsConfig != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		grpcServer = rpc.NewServer(rpc.NewMenuServer(menuService, eventBroker), opts...)
+	}
+
+	// Serve metrics on their own port when one is configured
+	var adminServer *http.Server
+	var adminListener net.Listener
+	if appMetrics != nil && cfg.Metrics.Port != "" {
+		adminListener, err = net.Listen("tcp", ":"+cfg.Metrics.Port)
+		if err != nil {
+			httpListener.Close()
+			if grpcListener != nil {
+				grpcListener.Close()
+			}
+			return fmt.Errorf("failed to listen for metrics: %w", err)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", appMetrics.Handler())
+		adminServer = &http.Server{
+			Handler:           mux,
+			TLSConfig:         tlsConfig,
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+			WriteTimeout:      cfg.Server.WriteTimeout,
+		}
+	}
+
+	serverErrors := make(chan error, 4)
+
+	// Start HTTP server
+	go func() {
+		slog.Info("Server starting", "port", cfg.Server.Port, "tls", tlsConfig != nil)
+		if err := serveHTTP(httpServer, httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+	}()
+
+	// Start HTTP/3 server
+	if h3Server != nil {
+		go func() {
+			slog.Info("HTTP/3 server starting", "port", cfg.Server.Port)
+			if err := h3Server.Serve(h3Conn); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErrors <- err
+			}
+		}()
+	}
+
+	// Start gRPC server
+	if grpcServer != nil {
+		go func() {
+			slog.Info("gRPC server starting", "port", cfg.GRPC.Port)
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				serverErrors <- err
+			}
+		}()
+	}
+
+	// Start admin server
+	if adminServer != nil {
+		go func() {
+			slog.Info("Metrics server starting", "port", cfg.Metrics.Port)
+			if err := serveHTTP(adminServer, adminListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErrors <- err
+			}
+		}()
+	}
+
+	// Wait for a shutdown signal or a server failure
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	var serveErr error
+	select {
+	case sig := <-quit:
+		slog.Info("Shutting down", "signal", sig.String())
+	case serveErr = <-serverErrors:
+		slog.Info("Shutting down after server failure")
+	}
+
+	// Drain the servers within the grace period
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer shutdownCancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("HTTP server shutdown error", "error", err)
+		}
+	}()
+
+	if h3Server != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := h3Server.Shutdown(shutdownCtx); err != nil {
+				slog.Error("HTTP/3 server shutdown error", "error", err)
+			}
+		}()
+	}
+
+	if grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stopGRPC(shutdownCtx, grpcServer, eventBroker)
+		}()
+	}
+
+	if adminServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := adminServer.Shutdown(shutdownCtx); err != nil {
+				slog.Error("Metrics server shutdown error", "error", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	slog.Info("Server stopped")
+	return serveErr
+}
 
-	// Start server
-	log.Printf("Server starting on port %s...", cfg.Server.Port)
-	if err := router.Run(":" + cfg.Server.Port); err != nil {
-		log.Fatal("Failed to start server:", err)
+// stopGRPC drains the gRPC server, forcing it to stop once ctx expires
+func stopGRPC(ctx context.Context, server *grpc.Server, broker *event.Broker) {
+	broker.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
 	}
 }
 
-func setupRouter(menuHandler *handler.MenuHandler, cfg *config.Config) *gin.Engine {
+// handlers groups the HTTP handlers mounted by setupRouter
+type handlers struct {
+	menu     *handler.MenuHandler
+	template *handler.MenuTemplateHandler
+	event    *handler.EventHandler
+	webhook  *handler.WebhookHandler
+	graphql  *handler.GraphQLHandler
+	docs     *handler.DocsHandler
+	health   *handler.HealthHandler
+	// metrics is nil when metrics are disabled
+	metrics *metrics.Metrics
+	// idempotency is nil when Idempotency-Key support is disabled
+	idempotency domain.IdempotencyRepository
+}
+
+func setupRouter(h *handlers, cfg *config.Config) (*gin.Engine, error) {
 	// Set Gin mode
 	if cfg.App.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.Default()
+	router := gin.New()
+
+	// Only trusted proxies may set the client IP used for rate limiting
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	// Request IDs first so that every later log line and response carries one
+	router.Use(
+		middleware.RequestID(),
+		middleware.Logger("/health", "/livez", "/readyz", "/metrics"),
+		middleware.Recovery(),
+	)
+
+	// One span per request, continuing the caller's W3C trace context
+	if cfg.Tracing.Enabled() {
+		router.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	}
+
+	// Request metrics, served here unless a separate metrics port is set
+	if h.metrics != nil {
+		router.Use(h.metrics.Middleware())
+		if cfg.Metrics.Port == "" {
+			router.GET("/metrics", handler.NewMetricsHandler(h.metrics.Handler()).GetMetrics)
+		}
+	}
 
 	// CORS Configuration
 	router.Use(cors.New(cors.Config{
-		AllowOrigins:     cfg.CORS.AllowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+		AllowOrigins: cfg.CORS.AllowedOrigins,
+		AllowMethods: cfg.CORS.AllowedMethods,
+		AllowHeaders: cfg.CORS.AllowedHeaders,
+		// Headers set by the API itself are always readable by browsers
+		ExposeHeaders: []string{
+			"Content-Length",
+			middleware.RequestIDHeader,
+			middleware.RateLimitLimitHeader,
+			middleware.RateLimitRemainingHeader,
+			middleware.RateLimitResetHeader,
+			middleware.RateLimitPolicyHeader,
+			middleware.RetryAfterHeader,
+			middleware.IdempotentReplayedHeader,
+		},
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
 	}))
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "ok",
-			"message": "Server Is Running",
-		})
-	})
+	router.Use(middleware.BodyLimit(cfg.Server.MaxBodyBytes))
+
+	// Per-client request budgets for the API; probes, docs and metrics are
+	// not limited
+	limit := func(c *gin.Context) { c.Next() }
+	if cfg.RateLimit.Enabled {
+		limit = middleware.RateLimit(
+			ratelimit.New(cfg.RateLimit.Reads, cfg.RateLimit.Window),
+			ratelimit.New(cfg.RateLimit.Writes, cfg.RateLimit.Window),
+		)
+	}
+
+	// Health check endpoints
+	router.GET("/health", handler.Health)
+	router.GET("/livez", h.health.Livez)
+	router.GET("/readyz", h.health.Readyz)
+
+	// API documentation
+	router.GET("/openapi.json", h.docs.GetOpenAPISpec)
+	router.GET("/docs", h.docs.GetDocs)
+
+	// Every request except long-lived streams gets a deadline
+	timeout := middleware.Timeout(cfg.Server.RequestTimeout)
+
+	// Retries of create requests with an Idempotency-Key get the first
+	// response. A request still running when the server would have cut it
+	// off is treated as lost.
+	idempotent := func(c *gin.Context) { c.Next() }
+	if h.idempotency != nil {
+		abandonAfter := max(cfg.Server.RequestTimeout, cfg.Server.WriteTimeout, time.Minute)
+		idempotent = middleware.Idempotency(h.idempotency, cfg.Idempotency.TTL, abandonAfter)
+	}
+
+	// GraphQL endpoint
+	router.POST("/graphql", limit, timeout, h.graphql.Query)
 
 	// API routes
-	api := router.Group("/api")
+	api := router.Group("/api", limit)
 	{
+		// Menu change stream
+		api.GET("/menus/events", h.event.StreamMenuEvents)
+
 		// Menu routes
-		menus := api.Group("/menus")
+		menus := api.Group("/menus", timeout)
 		{
-			menus.GET("/hierarchy", menuHandler.GetMenuHierarchy)
-			menus.GET("/root", menuHandler.GetRootMenus)
-			menus.GET("/uuid/:uuid", menuHandler.GetMenuByUUID)
-			menus.GET("/:id/hierarchy", menuHandler.GetHierarchyByRootID)
-			menus.GET("/:id/detail", menuHandler.GetMenuDetail)
-			menus.GET("/:id/children", menuHandler.GetChildrenByParentID)
-			menus.GET("", menuHandler.GetAllMenus)
-			menus.GET("/:id", menuHandler.GetMenuByID)
-			menus.POST("", menuHandler.CreateMenu)
-			menus.PUT("/:id", menuHandler.UpdateMenu)
-			menus.DELETE("/:id", menuHandler.DeleteMenu)
+			menus.GET("/hierarchy", h.menu.GetMenuHierarchy)
+			menus.GET("/root", h.menu.GetRootMenus)
+			menus.GET("/uuid/:uuid", h.menu.GetMenuByUUID)
+			menus.GET("/:id/hierarchy", h.menu.GetHierarchyByRootID)
+			menus.GET("/:id/detail", h.menu.GetMenuDetail)
+			menus.GET("/:id/children", h.menu.GetChildrenByParentID)
+			menus.GET("", h.menu.GetAllMenus)
+			menus.GET("/:id", h.menu.GetMenuByID)
+			menus.POST("", idempotent, h.menu.CreateMenu)
+			menus.POST("/:id/clone", idempotent, h.menu.CloneMenu)
+			menus.PUT("/:id", h.menu.UpdateMenu)
+			menus.DELETE("/:id", h.menu.DeleteMenu)
+		}
+
+		// Menu template routes
+		templates := api.Group("/menu-templates", timeout)
+		{
+			templates.GET("", h.template.GetAllMenuTemplates)
+			templates.GET("/:name", h.template.GetMenuTemplate)
+			templates.POST("", idempotent, h.template.CreateMenuTemplate)
+			templates.POST("/:name/instantiate", idempotent, h.template.InstantiateMenuTemplate)
+			templates.PUT("/:name", h.template.UpdateMenuTemplate)
+			templates.DELETE("/:name", h.template.DeleteMenuTemplate)
+		}
+
+		// Webhook routes
+		webhooks := api.Group("/webhooks", timeout)
+		{
+			webhooks.GET("", h.webhook.GetAllWebhooks)
+			webhooks.GET("/:id", h.webhook.GetWebhookByID)
+			webhooks.GET("/:id/deliveries", h.webhook.GetWebhookDeliveries)
+			webhooks.POST("", idempotent, h.webhook.CreateWebhook)
+			webhooks.PUT("/:id", h.webhook.UpdateWebhook)
+			webhooks.DELETE("/:id", h.webhook.DeleteWebhook)
 		}
 	}
 
-	return router
+	return router, nil
 }
-
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/metrics"
+	"stk-technical-test-api/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ginParam = regexp.MustCompile(`[:*](\w+)`)
+
+func TestRoutesAreDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var doc openapi.Document
+	if err := json.Unmarshal(openapi.Spec, &doc); err != nil {
+		t.Fatalf("embedded OpenAPI document is invalid: %v", err)
+	}
+
+	cfg := &config.Config{
+		CORS: config.CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}},
+	}
+	router, err := setupRouter(&handlers{metrics: metrics.New()}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, route := range router.Routes() {
+		path := ginParam.ReplaceAllString(route.Path, "{$1}")
+		if _, ok := doc.Paths[path][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("route %s %s is not documented; add swag annotations to %s and run go generate ./internal/openapi",
+				route.Method, route.Path, route.Handler)
+		}
+	}
+}
+
+func TestSpecIsUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	dirs := make([]string, 0, len(openapi.SourceDirs))
+	for _, dir := range openapi.SourceDirs {
+		dirs = append(dirs, filepath.Join(root, dir))
+	}
+
+	doc, err := openapi.Generate(dirs...)
+	if err != nil {
+		t.Fatalf("failed to generate OpenAPI document: %v", err)
+	}
+
+	generated, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode OpenAPI document: %v", err)
+	}
+
+	if !bytes.Equal(append(generated, '\n'), openapi.Spec) {
+		t.Error("internal/openapi/openapi.json is stale; run go generate ./internal/openapi")
+	}
+}
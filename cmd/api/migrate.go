@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"stk-technical-test-api/database/migrations"
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/database"
+	"stk-technical-test-api/internal/migration"
+)
+
+// runMigrate implements the migrate subcommand
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate action\n\n%s", usage)
+	}
+
+	db, err := database.NewDatabase(cfg.Database, cfg.Log.SlowQueryThreshold)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		slog.Info("Applied migrations", "count", applied)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		slog.Info("Rolled back migrations", "count", reverted)
+		return err
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(status)
+		return nil
+	case "force":
+		if len(args) < 2 {
+			return fmt.Errorf("missing version\n\n%s", usage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := migrator.Force(ctx, uint(version)); err != nil {
+			return err
+		}
+		slog.Info("Schema version set", "version", version)
+		return nil
+	}
+	return fmt.Errorf("unknown migrate action %q\n\n%s", args[0], usage)
+}
+
+func printStatus(status *migration.Status) {
+	dirty := ""
+	if status.Dirty {
+		dirty = " (dirty)"
+	}
+	fmt.Printf("Current version: %d%s\n", status.Version, dirty)
+	fmt.Printf("Latest version:  %d\n", status.Latest)
+
+	if len(status.Pending) == 0 {
+		fmt.Println("Schema is up to date")
+		return
+	}
+	fmt.Println("Pending migrations:")
+	for _, m := range status.Pending {
+		fmt.Printf("  %06d_%s\n", m.Version, m.Name)
+	}
+}
+
+// prepareSchema applies pending migrations when auto-migrate is enabled and
+// refuses to continue if the schema is still behind this build
+func prepareSchema(ctx context.Context, cfg *config.Config, migrator *migration.Migrator) error {
+	if cfg.Database.AutoMigrate {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if applied > 0 {
+			slog.Info("Applied migrations", "count", applied)
+		}
+	}
+
+	return migrator.CheckCurrent(ctx)
+}
+
+// newMigrator creates a migrator for the embedded migrations of the
+// configured driver
+func newMigrator(cfg *config.Config, db *database.Database) (*migration.Migrator, error) {
+	return migration.New(db.GetDB(), migrations.FS, cfg.Database.Driver)
+}
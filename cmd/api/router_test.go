@@ -0,0 +1,502 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/event"
+	"stk-technical-test-api/internal/graph"
+	"stk-technical-test-api/internal/handler"
+	"stk-technical-test-api/internal/metrics"
+	"stk-technical-test-api/internal/openapi"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeTest drives the router of setupRouter over HTTP and records which
+// routes were exercised
+type routeTest struct {
+	server  *httptest.Server
+	routes  gin.RoutesInfo
+	covered map[string]bool
+}
+
+// envelope is the JSON body of every API response
+type envelope struct {
+	Success bool            `json:"success"`
+	Code    string          `json:"code"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// newRouteTest serves the full router with the menu API on the in-memory
+// repository. Webhooks have no in-memory repository and use SQLite.
+func newRouteTest(t *testing.T) *routeTest {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			RequestTimeout: 5 * time.Second,
+			MaxBodyBytes:   1 << 20,
+		},
+		CORS: config.CORSConfig{
+			AllowedOrigins: []string{"http://localhost:3000"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		},
+		Menu:        config.MenuConfig{MaxDepth: 3, MaxChildren: 10},
+		Idempotency: config.IdempotencyConfig{Enabled: true, TTL: time.Hour},
+	}
+
+	db := dbtest.Open(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := event.NewBroker(100)
+	t.Cleanup(broker.Close)
+	menuService := service.NewMenuService(repository.NewMemoryMenuRepository(), broker, menuLimits(cfg))
+	templateService := service.NewMenuTemplateService(repository.NewMenuTemplateRepository(db), menuService, menuLimits(cfg))
+	schema, err := graph.NewSchema(menuService)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router, err := setupRouter(&handlers{
+		menu:        handler.NewMenuHandler(menuService),
+		template:    handler.NewMenuTemplateHandler(templateService),
+		event:       handler.NewEventHandler(broker),
+		webhook:     handler.NewWebhookHandler(service.NewWebhookService(repository.NewWebhookRepository(db), false)),
+		graphql:     handler.NewGraphQLHandler(schema),
+		docs:        handler.NewDocsHandler(openapi.Spec),
+		health:      handler.NewHealthHandler(time.Second, handler.HealthCheck{Name: "database", Check: sqlDB.PingContext}),
+		metrics:     metrics.New(),
+		idempotency: repository.NewIdempotencyRepository(db),
+	}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return &routeTest{server: server, routes: router.Routes(), covered: make(map[string]bool)}
+}
+
+// do sends a request to path, marks route ("GET /api/menus/:id") as covered
+// and checks the status code. A non-nil body is sent as JSON.
+func (rt *routeTest) do(t *testing.T, route, path string, body any, wantStatus int) []byte {
+	t.Helper()
+
+	method, _, _ := strings.Cut(route, " ")
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, rt.server.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := rt.server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%s %s: status %d, want %d: %s", method, path, resp.StatusCode, wantStatus, raw)
+	}
+	if resp.Header.Get("X-Request-ID") == "" {
+		t.Errorf("%s %s: no X-Request-ID header", method, path)
+	}
+
+	rt.covered[route] = true
+	return raw
+}
+
+// api calls an API route and decodes the response envelope, checking its
+// error code when the status is not a success
+func (rt *routeTest) api(t *testing.T, route, path string, body any, wantStatus int, wantCode string) envelope {
+	t.Helper()
+
+	var env envelope
+	if err := json.Unmarshal(rt.do(t, route, path, body, wantStatus), &env); err != nil {
+		t.Fatalf("%s: invalid JSON response: %v", route, err)
+	}
+	if env.Success != (wantStatus < 300) || env.Code != wantCode {
+		t.Fatalf("%s: success %t, code %q, want code %q", route, env.Success, env.Code, wantCode)
+	}
+	return env
+}
+
+func decode[T any](t *testing.T, env envelope) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(env.Data, &v); err != nil {
+		t.Fatalf("invalid response data %s: %v", env.Data, err)
+	}
+	return v
+}
+
+func TestRoutes(t *testing.T) {
+	rt := newRouteTest(t)
+
+	t.Run("probes", func(t *testing.T) {
+		rt.do(t, "GET /health", "/health", nil, http.StatusOK)
+		rt.do(t, "GET /livez", "/livez", nil, http.StatusOK)
+		rt.do(t, "GET /readyz", "/readyz", nil, http.StatusOK)
+	})
+
+	t.Run("docs and metrics", func(t *testing.T) {
+		var spec map[string]any
+		if err := json.Unmarshal(rt.do(t, "GET /openapi.json", "/openapi.json", nil, http.StatusOK), &spec); err != nil {
+			t.Errorf("invalid OpenAPI document: %v", err)
+		}
+		if page := rt.do(t, "GET /docs", "/docs", nil, http.StatusOK); !bytes.Contains(page, []byte("<html")) {
+			t.Error("docs page is not HTML")
+		}
+		if body := rt.do(t, "GET /metrics", "/metrics", nil, http.StatusOK); !bytes.Contains(body, []byte("http_requests_total")) {
+			t.Error("metrics do not include request counts")
+		}
+	})
+
+	t.Run("menus", func(t *testing.T) {
+		env := rt.api(t, "POST /api/menus", "/api/menus",
+			domain.CreateMenuRequest{Name: "System", Code: "system", IsActive: true}, http.StatusCreated, "")
+		root := decode[domain.Menu](t, env)
+
+		env = rt.api(t, "POST /api/menus", "/api/menus",
+			domain.CreateMenuRequest{ParentID: &root.ID, Name: "Users", Code: "users", IsActive: false}, http.StatusCreated, "")
+		child := decode[domain.Menu](t, env)
+		if child.Level != 1 || child.IsActive {
+			t.Errorf("created child = %+v", child)
+		}
+
+		rt.api(t, "POST /api/menus", "/api/menus",
+			domain.CreateMenuRequest{Name: "Duplicate", Code: "system"}, http.StatusConflict, "conflict")
+		rt.api(t, "POST /api/menus", "/api/menus",
+			map[string]any{"name": "Unknown", "code": "unknown", "colour": "red"}, http.StatusBadRequest, "invalid_request")
+		rt.api(t, "POST /api/menus", "/api/menus",
+			domain.CreateMenuRequest{Name: "No code"}, http.StatusUnprocessableEntity, "validation_failed")
+
+		menus := decode[[]domain.Menu](t, rt.api(t, "GET /api/menus", "/api/menus", nil, http.StatusOK, ""))
+		if len(menus) != 2 {
+			t.Errorf("listed %d menus, want 2", len(menus))
+		}
+
+		roots := decode[[]domain.Menu](t, rt.api(t, "GET /api/menus/root", "/api/menus/root", nil, http.StatusOK, ""))
+		if len(roots) != 1 || roots[0].ID != root.ID {
+			t.Errorf("root menus = %+v", roots)
+		}
+
+		tree := decode[[]domain.Menu](t, rt.api(t, "GET /api/menus/hierarchy", "/api/menus/hierarchy", nil, http.StatusOK, ""))
+		if len(tree) != 1 || len(tree[0].Children) != 1 || tree[0].Children[0].ID != child.ID {
+			t.Errorf("hierarchy = %+v", tree)
+		}
+
+		path := fmt.Sprintf("/api/menus/%d", root.ID)
+		got := decode[domain.Menu](t, rt.api(t, "GET /api/menus/:id", path, nil, http.StatusOK, ""))
+		if got.UUID != root.UUID {
+			t.Errorf("menu = %+v", got)
+		}
+		rt.api(t, "GET /api/menus/:id", "/api/menus/999", nil, http.StatusNotFound, "not_found")
+		rt.api(t, "GET /api/menus/:id", "/api/menus/abc", nil, http.StatusBadRequest, "invalid_request")
+
+		got = decode[domain.Menu](t, rt.api(t, "GET /api/menus/uuid/:uuid", "/api/menus/uuid/"+child.UUID, nil, http.StatusOK, ""))
+		if got.ID != child.ID {
+			t.Errorf("menu by UUID = %+v", got)
+		}
+
+		tree = decode[[]domain.Menu](t, rt.api(t, "GET /api/menus/:id/hierarchy", path+"/hierarchy", nil, http.StatusOK, ""))
+		if len(tree) != 1 || len(tree[0].Children) != 1 {
+			t.Errorf("subtree = %+v", tree)
+		}
+
+		detail := decode[domain.MenuDetail](t, rt.api(t, "GET /api/menus/:id/detail",
+			fmt.Sprintf("/api/menus/%d/detail", child.ID), nil, http.StatusOK, ""))
+		if detail.ParentData == nil || detail.ParentData.ID != root.ID || detail.Depth != 1 {
+			t.Errorf("detail = %+v", detail)
+		}
+
+		children := decode[[]domain.Menu](t, rt.api(t, "GET /api/menus/:id/children", path+"/children", nil, http.StatusOK, ""))
+		if len(children) != 1 || children[0].ID != child.ID {
+			t.Errorf("children = %+v", children)
+		}
+
+		cloneReq := domain.CloneMenuRequest{Name: "System copy", CodePattern: `^`, CodeReplacement: "copy."}
+		clone := decode[domain.Menu](t, rt.api(t, "POST /api/menus/:id/clone", path+"/clone", cloneReq, http.StatusCreated, ""))
+		if clone.Code != "copy.system" || clone.Name != "System copy" || clone.UUID == root.UUID || clone.ParentID != nil ||
+			len(clone.Children) != 1 || clone.Children[0].Code != "copy.users" || clone.Children[0].Level != 1 {
+			t.Errorf("clone = %+v", clone)
+		}
+
+		// Cloning again reports the taken name and every taken code and
+		// writes nothing
+		var rejected struct {
+			Violations []domain.FieldViolation `json:"violations"`
+		}
+		body := rt.do(t, "POST /api/menus/:id/clone", path+"/clone", cloneReq, http.StatusUnprocessableEntity)
+		if err := json.Unmarshal(body, &rejected); err != nil {
+			t.Fatal(err)
+		}
+		if len(rejected.Violations) != 3 {
+			t.Errorf("violations = %+v, want the name and both codes", rejected.Violations)
+		}
+		rt.api(t, "POST /api/menus/:id/clone", "/api/menus/999/clone", domain.CloneMenuRequest{CodePrefix: "x."}, http.StatusNotFound, "not_found")
+
+		rt.api(t, "DELETE /api/menus/:id", fmt.Sprintf("/api/menus/%d", clone.Children[0].ID), nil, http.StatusOK, "")
+		rt.api(t, "DELETE /api/menus/:id", fmt.Sprintf("/api/menus/%d", clone.ID), nil, http.StatusOK, "")
+
+		route := "/users"
+		updated := decode[domain.Menu](t, rt.api(t, "PUT /api/menus/:id", fmt.Sprintf("/api/menus/%d", child.ID),
+			domain.UpdateMenuRequest{Name: "People", Code: "people", Route: &route, IsActive: true}, http.StatusOK, ""))
+		if updated.Name != "People" || updated.ParentID != nil || updated.Level != 0 {
+			t.Errorf("updated menu = %+v", updated)
+		}
+
+		rt.api(t, "PUT /api/menus/:id", path,
+			domain.UpdateMenuRequest{ParentID: &root.ID, Name: "System", Code: "system"}, http.StatusUnprocessableEntity, "cycle")
+
+		rt.api(t, "DELETE /api/menus/:id", fmt.Sprintf("/api/menus/%d", child.ID), nil, http.StatusOK, "")
+		rt.api(t, "DELETE /api/menus/:id", fmt.Sprintf("/api/menus/%d", child.ID), nil, http.StatusNotFound, "not_found")
+	})
+
+	t.Run("menu templates", func(t *testing.T) {
+		route := "/{{module}}"
+		tmpl := decode[domain.MenuTemplate](t, rt.api(t, "POST /api/menu-templates", "/api/menu-templates", domain.CreateMenuTemplateRequest{
+			Name: "crud-module",
+			Tree: domain.MenuTemplateNode{
+				Name: "{{title}}", Code: "{{module}}", Route: &route, IsActive: true,
+				Children: []domain.MenuTemplateNode{
+					{Name: "List {{title}}", Code: "{{module}}.list"},
+					{Name: "New {{title}}", Code: "{{module}}.create", OrderIndex: 1},
+				},
+			},
+		}, http.StatusCreated, ""))
+		if !slices.Equal(tmpl.Variables, []string{"module", "title"}) {
+			t.Errorf("template variables = %v", tmpl.Variables)
+		}
+
+		rt.api(t, "POST /api/menu-templates", "/api/menu-templates", domain.CreateMenuTemplateRequest{
+			Name: "crud-module", Tree: domain.MenuTemplateNode{Name: "Other", Code: "other"},
+		}, http.StatusConflict, "conflict")
+		rt.api(t, "POST /api/menu-templates", "/api/menu-templates", domain.CreateMenuTemplateRequest{
+			Name: "broken", Tree: domain.MenuTemplateNode{Name: "{{Title}}", Code: "Broken.{{module}}"},
+		}, http.StatusUnprocessableEntity, "validation_failed")
+
+		templates := decode[[]domain.MenuTemplate](t, rt.api(t, "GET /api/menu-templates", "/api/menu-templates", nil, http.StatusOK, ""))
+		if len(templates) != 1 || templates[0].Name != "crud-module" {
+			t.Errorf("templates = %+v", templates)
+		}
+		got := decode[domain.MenuTemplate](t, rt.api(t, "GET /api/menu-templates/:name", "/api/menu-templates/crud-module", nil, http.StatusOK, ""))
+		if len(got.Tree.Children) != 2 {
+			t.Errorf("template = %+v", got)
+		}
+		rt.api(t, "GET /api/menu-templates/:name", "/api/menu-templates/missing", nil, http.StatusNotFound, "not_found")
+
+		instantiate := domain.InstantiateMenuTemplateRequest{Variables: map[string]string{"module": "invoices", "title": "Invoices"}}
+		root := decode[domain.Menu](t, rt.api(t, "POST /api/menu-templates/:name/instantiate",
+			"/api/menu-templates/crud-module/instantiate", instantiate, http.StatusCreated, ""))
+		if root.Code != "invoices" || root.Name != "Invoices" || root.Route == nil || *root.Route != "/invoices" ||
+			len(root.Children) != 2 || root.Children[1].Code != "invoices.create" || root.Children[1].Level != 1 {
+			t.Errorf("instantiated menus = %+v", root)
+		}
+
+		// The same values again collide with the menus just created
+		rt.api(t, "POST /api/menu-templates/:name/instantiate", "/api/menu-templates/crud-module/instantiate",
+			instantiate, http.StatusUnprocessableEntity, "validation_failed")
+		rt.api(t, "POST /api/menu-templates/:name/instantiate", "/api/menu-templates/crud-module/instantiate",
+			domain.InstantiateMenuTemplateRequest{Variables: map[string]string{"module": "bills"}}, http.StatusUnprocessableEntity, "validation_failed")
+
+		updated := decode[domain.MenuTemplate](t, rt.api(t, "PUT /api/menu-templates/:name", "/api/menu-templates/crud-module",
+			domain.UpdateMenuTemplateRequest{Name: "module", Tree: domain.MenuTemplateNode{Name: "{{title}}", Code: "{{module}}"}}, http.StatusOK, ""))
+		if updated.Name != "module" || !slices.Equal(updated.Variables, []string{"module", "title"}) {
+			t.Errorf("updated template = %+v", updated)
+		}
+
+		rt.api(t, "DELETE /api/menu-templates/:name", "/api/menu-templates/module", nil, http.StatusOK, "")
+		rt.api(t, "DELETE /api/menu-templates/:name", "/api/menu-templates/module", nil, http.StatusNotFound, "not_found")
+
+		for _, child := range root.Children {
+			rt.api(t, "DELETE /api/menus/:id", fmt.Sprintf("/api/menus/%d", child.ID), nil, http.StatusOK, "")
+		}
+		rt.api(t, "DELETE /api/menus/:id", fmt.Sprintf("/api/menus/%d", root.ID), nil, http.StatusOK, "")
+	})
+
+	t.Run("menu events", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Replay the events of the menu tests after the first one
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rt.server.URL+"/api/menus/events", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Last-Event-ID", "1")
+		resp, err := rt.server.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+			t.Fatalf("status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		line, err := bufio.NewReader(resp.Body).ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != "id:2\n" {
+			t.Errorf("first line = %q, want id:2", line)
+		}
+		rt.covered["GET /api/menus/events"] = true
+	})
+
+	t.Run("graphql", func(t *testing.T) {
+		body := rt.do(t, "POST /graphql", "/graphql",
+			map[string]string{"query": `{ menus(rootOnly: true) { code } }`}, http.StatusOK)
+
+		var result struct {
+			Data struct {
+				Menus []struct{ Code string }
+			}
+			Errors []any
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Errors) > 0 || len(result.Data.Menus) != 1 || result.Data.Menus[0].Code != "system" {
+			t.Errorf("GraphQL response = %s", body)
+		}
+	})
+
+	t.Run("webhooks", func(t *testing.T) {
+		env := rt.api(t, "POST /api/webhooks", "/api/webhooks", domain.CreateWebhookRequest{
+			URL:        "https://example.com/hooks/menus",
+			Secret:     "0123456789abcdef",
+			EventTypes: []string{string(domain.MenuEventCreated)},
+			IsActive:   true,
+		}, http.StatusCreated, "")
+		sub := decode[domain.WebhookSubscription](t, env)
+
+		rt.api(t, "POST /api/webhooks", "/api/webhooks",
+			domain.CreateWebhookRequest{URL: "ftp://example.com", Secret: "0123456789abcdef"}, http.StatusUnprocessableEntity, "validation_failed")
+
+		subs := decode[[]domain.WebhookSubscription](t, rt.api(t, "GET /api/webhooks", "/api/webhooks", nil, http.StatusOK, ""))
+		if len(subs) != 1 {
+			t.Errorf("listed %d webhooks, want 1", len(subs))
+		}
+
+		path := fmt.Sprintf("/api/webhooks/%d", sub.ID)
+		got := decode[domain.WebhookSubscription](t, rt.api(t, "GET /api/webhooks/:id", path, nil, http.StatusOK, ""))
+		if got.URL != sub.URL || !got.IsActive {
+			t.Errorf("webhook = %+v", got)
+		}
+		rt.api(t, "GET /api/webhooks/:id", "/api/webhooks/999", nil, http.StatusNotFound, "not_found")
+
+		deliveries := decode[[]domain.WebhookDelivery](t, rt.api(t, "GET /api/webhooks/:id/deliveries", path+"/deliveries", nil, http.StatusOK, ""))
+		if len(deliveries) != 0 {
+			t.Errorf("new webhook has %d deliveries", len(deliveries))
+		}
+
+		got = decode[domain.WebhookSubscription](t, rt.api(t, "PUT /api/webhooks/:id", path, domain.UpdateWebhookRequest{
+			URL: "https://example.com/hooks/v2",
+		}, http.StatusOK, ""))
+		if got.URL != "https://example.com/hooks/v2" || got.IsActive {
+			t.Errorf("updated webhook = %+v", got)
+		}
+
+		rt.api(t, "DELETE /api/webhooks/:id", path, nil, http.StatusOK, "")
+		rt.api(t, "GET /api/webhooks/:id", path, nil, http.StatusNotFound, "not_found")
+	})
+
+	t.Run("idempotency", func(t *testing.T) {
+		post := func(key string, body any, wantStatus int) *http.Response {
+			t.Helper()
+			raw, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err := http.NewRequest(http.MethodPost, rt.server.URL+"/api/menus", bytes.NewReader(raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Idempotency-Key", key)
+			resp, err := rt.server.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != wantStatus {
+				t.Fatalf("POST /api/menus: status %d, want %d", resp.StatusCode, wantStatus)
+			}
+			return resp
+		}
+		readBody := func(resp *http.Response) string {
+			t.Helper()
+			defer resp.Body.Close()
+			raw, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return string(raw)
+		}
+
+		req := domain.CreateMenuRequest{Name: "Reports", Code: "reports"}
+		first := post("create-reports", req, http.StatusCreated)
+		if first.Header.Get("Idempotent-Replayed") != "" {
+			t.Error("first response marked as replayed")
+		}
+		created := readBody(first)
+
+		retry := post("create-reports", req, http.StatusCreated)
+		if retry.Header.Get("Idempotent-Replayed") != "true" {
+			t.Error("retry not marked as replayed")
+		}
+		if got := readBody(retry); got != created {
+			t.Errorf("replayed body = %s, want %s", got, created)
+		}
+
+		reused := readBody(post("create-reports", domain.CreateMenuRequest{Name: "Other", Code: "other"}, http.StatusUnprocessableEntity))
+		if !strings.Contains(reused, `"idempotency_key_reused"`) {
+			t.Errorf("reused key response = %s", reused)
+		}
+
+		// Without a key the request runs again and hits the stored menu
+		rt.api(t, "POST /api/menus", "/api/menus", req, http.StatusUnprocessableEntity, "validation_failed")
+	})
+
+	for _, route := range rt.routes {
+		if key := route.Method + " " + route.Path; !rt.covered[key] {
+			t.Errorf("route %s is not tested", key)
+		}
+	}
+}
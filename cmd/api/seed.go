@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"strings"
+
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/database"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/seed"
+	"stk-technical-test-api/internal/service"
+)
+
+// runSeed implements the seed subcommand
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fixtures := flags.String("fixture", "default", "comma separated fixtures to load in order: "+strings.Join(seed.Fixtures, ", "))
+	size := flags.Int("size", 0, "number of menus in generated fixtures")
+	depth := flags.Int("depth", 0, "number of levels in generated fixtures")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	// Build every fixture first so a typo fails before anything is written
+	limits := menuLimits(cfg)
+	names := strings.Split(*fixtures, ",")
+	trees := make([][]seed.Node, len(names))
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		nodes, err := seed.Load(names[i], seed.Options{Size: *size, Depth: *depth}, limits)
+		if err != nil {
+			return err
+		}
+		trees[i] = nodes
+	}
+
+	db, err := database.NewDatabase(cfg.Database, cfg.Log.SlowQueryThreshold)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err := prepareSchema(ctx, cfg, migrator); err != nil {
+		return err
+	}
+
+	menuService := service.NewMenuService(repository.NewMenuRepository(db.GetDB()), nil, limits)
+	seeder := seed.NewSeeder(menuService)
+	for i, nodes := range trees {
+		result, err := seeder.Seed(ctx, nodes)
+		slog.Info("Seeded fixture", "fixture", names[i], "created", result.Created, "existing", result.Skipped)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/tlscert"
+
+	"github.com/quic-go/quic-go/http3"
+)
+
+// newTLSConfig loads the server certificate and, when a reload interval is
+// set, watches its files on the workers until ctx is cancelled. It returns
+// nil when TLS is disabled.
+func newTLSConfig(ctx context.Context, cfg config.TLSConfig, workers *sync.WaitGroup) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	reloader, err := tlscert.NewReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := tlscert.ServerConfig(reloader, cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ReloadInterval > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			reloader.Watch(ctx, cfg.ReloadInterval)
+		}()
+	}
+	return tlsConfig, nil
+}
+
+// serveHTTP serves srv on ln, over TLS with HTTP/2 when srv has a TLS
+// configuration
+func serveHTTP(srv *http.Server, ln net.Listener) error {
+	if srv.TLSConfig != nil {
+		return srv.ServeTLS(ln, "", "")
+	}
+	return srv.Serve(ln)
+}
+
+// advertiseHTTP3 adds the Alt-Svc header pointing clients of the TCP
+// server at the HTTP/3 server
+func advertiseHTTP3(h3 *http3.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fails only until the UDP listener is up; the header is optional
+		_ = h3.SetQUICHeaders(w.Header())
+		next.ServeHTTP(w, r)
+	})
+}
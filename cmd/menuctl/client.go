@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/middleware"
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/google/uuid"
+)
+
+// Requests sent with an Idempotency-Key are retried this many times in all
+// after network errors, server errors and while the first try is running
+const (
+	maxAttempts  = 3
+	retryBackoff = 500 * time.Millisecond
+)
+
+type idempotencyKeyContext struct{}
+
+// withIdempotencyKey makes CreateMenu send key instead of a random one, so
+// that running the same command again replays its responses
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContext{}, key)
+}
+
+// restClient implements menuClient on top of the REST API
+type restClient struct {
+	baseURL      string
+	http         *http.Client
+	retryBackoff time.Duration
+}
+
+func newRESTClient(baseURL string) *restClient {
+	return &restClient{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		http:         http.DefaultClient,
+		retryBackoff: retryBackoff,
+	}
+}
+
+// apiResponse is response.Response with the data left undecoded
+type apiResponse struct {
+	Success    bool                 `json:"success"`
+	Message    string               `json:"message"`
+	Code       string               `json:"code"`
+	Data       json.RawMessage      `json:"data"`
+	Error      any                  `json:"error"`
+	Violations []response.Violation `json:"violations"`
+}
+
+func (c *restClient) CreateMenu(ctx context.Context, req *domain.CreateMenuRequest) (*domain.Menu, error) {
+	key, _ := ctx.Value(idempotencyKeyContext{}).(string)
+	if key == "" {
+		key = uuid.NewString()
+	}
+
+	var menu domain.Menu
+	if err := c.doIdempotent(ctx, http.MethodPost, "/api/menus", key, req, &menu); err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+func (c *restClient) UpdateMenu(ctx context.Context, id int64, req *domain.UpdateMenuRequest) (*domain.Menu, error) {
+	var menu domain.Menu
+	if err := c.do(ctx, http.MethodPut, menuPath(id), req, &menu); err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+func (c *restClient) DeleteMenu(ctx context.Context, id int64) error {
+	return c.do(ctx, http.MethodDelete, menuPath(id), nil, nil)
+}
+
+func (c *restClient) GetMenuByID(ctx context.Context, id int64) (*domain.Menu, error) {
+	var menu domain.Menu
+	if err := c.do(ctx, http.MethodGet, menuPath(id), nil, &menu); err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+func (c *restClient) GetAllMenus(ctx context.Context) ([]domain.Menu, error) {
+	var menus []domain.Menu
+	if err := c.do(ctx, http.MethodGet, "/api/menus", nil, &menus); err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+func (c *restClient) GetMenuHierarchy(ctx context.Context) ([]domain.Menu, error) {
+	var menus []domain.Menu
+	if err := c.do(ctx, http.MethodGet, "/api/menus/hierarchy", nil, &menus); err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+func menuPath(id int64) string {
+	return "/api/menus/" + strconv.FormatInt(id, 10)
+}
+
+// do sends a request and decodes the data of a successful response into
+// out. Failed responses are turned back into domain errors.
+func (c *restClient) do(ctx context.Context, method, path string, body, out any) error {
+	return c.doIdempotent(ctx, method, path, "", body, out)
+}
+
+// doIdempotent is do with an Idempotency-Key. A request with a key is
+// retried, since the server answers a repeated request only once.
+func (c *restClient) doIdempotent(ctx context.Context, method, path, key string, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	attempts := 1
+	if key != "" {
+		attempts = maxAttempts
+	}
+
+	var (
+		result *apiResponse
+		err    error
+	)
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		result, retry, err = c.send(ctx, method, path, key, payload)
+		if !retry || attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * c.retryBackoff):
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return responseError(result)
+	}
+	if out == nil || len(result.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// send makes one request and reports whether a failure may be retried
+func (c *restClient) send(ctx context.Context, method, path, key string, payload []byte) (*apiResponse, bool, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("request to %s failed: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	var result apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, resp.StatusCode >= http.StatusInternalServerError,
+			fmt.Errorf("unexpected response from %s %s: %s", method, path, resp.Status)
+	}
+
+	retry := resp.StatusCode >= http.StatusInternalServerError ||
+		result.Code == response.CodeIdempotencyKeyInProgress
+	return &result, retry, nil
+}
+
+// responseError maps the error code of a failed response to its domain kind
+func responseError(result *apiResponse) error {
+	message := result.Message
+	if detail, ok := result.Error.(string); ok && detail != "" {
+		message = detail
+	}
+
+	var kind error
+	switch result.Code {
+	case response.CodeNotFound:
+		kind = domain.ErrNotFound
+	case response.CodeConflict:
+		kind = domain.ErrConflict
+	case response.CodeHasChildren:
+		kind = domain.ErrHasChildren
+	case response.CodeCycle:
+		kind = domain.ErrCycle
+	case response.CodeValidationFailed, response.CodeInvalidRequest:
+		kind = domain.ErrValidation
+	default:
+		return fmt.Errorf("%s (%s)", message, result.Code)
+	}
+
+	violations := make([]domain.FieldViolation, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		violations = append(violations, domain.FieldViolation{Field: v.Field, Message: v.Message})
+	}
+	return &domain.Error{Kind: kind, Message: message, Violations: violations}
+}
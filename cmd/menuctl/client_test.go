@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/handler"
+	"stk-technical-test-api/internal/middleware"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// menuAPI serves the menu routes of the API on the in-memory repository,
+// with Idempotency-Key support, and records the keys it receives
+type menuAPI struct {
+	server  *httptest.Server
+	service domain.MenuService
+
+	mu   sync.Mutex
+	keys []string
+	// failNext answers the next request with 503 before it reaches the API
+	failNext bool
+}
+
+func newMenuAPI(t *testing.T) *menuAPI {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	api := &menuAPI{
+		service: service.NewMenuService(repository.NewMemoryMenuRepository(), nil, domain.MenuLimits{}),
+	}
+	menus := handler.NewMenuHandler(api.service)
+	idempotent := middleware.Idempotency(repository.NewIdempotencyRepository(dbtest.Open(t)), time.Hour, time.Minute)
+
+	router := gin.New()
+	router.Use(api.record)
+	router.GET("/api/menus", menus.GetAllMenus)
+	router.GET("/api/menus/hierarchy", menus.GetMenuHierarchy)
+	router.GET("/api/menus/:id", menus.GetMenuByID)
+	router.POST("/api/menus", idempotent, menus.CreateMenu)
+	router.PUT("/api/menus/:id", menus.UpdateMenu)
+	router.DELETE("/api/menus/:id", menus.DeleteMenu)
+
+	api.server = httptest.NewServer(router)
+	t.Cleanup(api.server.Close)
+	return api
+}
+
+func (api *menuAPI) record(c *gin.Context) {
+	api.mu.Lock()
+	if key := c.GetHeader(middleware.IdempotencyKeyHeader); key != "" {
+		api.keys = append(api.keys, key)
+	}
+	fail := api.failNext
+	api.failNext = false
+	api.mu.Unlock()
+
+	if fail {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"success": false, "code": "unavailable"})
+	}
+}
+
+// takeKeys returns and forgets the keys received so far
+func (api *menuAPI) takeKeys() []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	keys := api.keys
+	api.keys = nil
+	return keys
+}
+
+func (api *menuAPI) client() *restClient {
+	client := newRESTClient(api.server.URL)
+	client.retryBackoff = time.Millisecond
+	return client
+}
+
+func TestCreateRetriesWithTheSameKey(t *testing.T) {
+	api := newMenuAPI(t)
+	api.failNext = true
+
+	menu, err := api.client().CreateMenu(context.Background(), &domain.CreateMenuRequest{Name: "Users", Code: "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if menu.Code != "users" {
+		t.Errorf("created menu = %+v", menu)
+	}
+
+	keys := api.takeKeys()
+	if len(keys) != 2 || keys[0] != keys[1] {
+		t.Errorf("keys sent = %q, want the same key twice", keys)
+	}
+}
+
+func TestImportAgainReplaysCreatedMenus(t *testing.T) {
+	api := newMenuAPI(t)
+	file := `[{"name": "Settings", "code": "settings", "is_active": true,
+	           "children": [{"name": "Users", "code": "settings.users", "is_active": true}]}]`
+
+	importFile := func() error {
+		cmd := &command{client: api.client(), out: &printer{w: io.Discard}, stdin: strings.NewReader(file)}
+		return cmd.run(context.Background(), "import", []string{"-"})
+	}
+
+	if err := importFile(); err != nil {
+		t.Fatal(err)
+	}
+	first := api.takeKeys()
+
+	// Running the same import again succeeds without creating duplicates
+	if err := importFile(); err != nil {
+		t.Fatalf("second import failed: %v", err)
+	}
+	second := api.takeKeys()
+
+	if len(first) != 2 || strings.Join(first, ",") != strings.Join(second, ",") || first[0] == first[1] {
+		t.Errorf("keys = %q then %q, want the same two keys", first, second)
+	}
+
+	menus, err := api.service.GetAllMenus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(menus) != 2 {
+		t.Errorf("%d menus after importing twice, want 2", len(menus))
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// command runs a single menuctl command against a menu client
+type command struct {
+	client menuClient
+	out    *printer
+	stdin  io.Reader
+}
+
+func (c *command) run(ctx context.Context, name string, args []string) error {
+	switch name {
+	case "list":
+		return c.list(ctx, args)
+	case "tree":
+		return c.tree(ctx, args)
+	case "get":
+		return c.get(ctx, args)
+	case "create":
+		return c.create(ctx, args)
+	case "update":
+		return c.update(ctx, args)
+	case "move":
+		return c.move(ctx, args)
+	case "delete":
+		return c.delete(ctx, args)
+	case "export":
+		return c.export(ctx, args)
+	case "import":
+		return c.importFile(ctx, args)
+	case "help":
+		_, err := fmt.Fprintln(c.out.w, usage)
+		return err
+	}
+	return usagef("unknown command %q, run menuctl help", name)
+}
+
+func (c *command) list(ctx context.Context, args []string) error {
+	fs := newFlagSet("list")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	menus, err := c.client.GetAllMenus(ctx)
+	if err != nil {
+		return err
+	}
+	return c.out.menus(menus)
+}
+
+func (c *command) tree(ctx context.Context, args []string) error {
+	fs := newFlagSet("tree [ID]")
+	positional, err := parseArgs(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	roots, err := c.subtree(ctx, positional)
+	if err != nil {
+		return err
+	}
+	return c.out.tree(roots)
+}
+
+func (c *command) get(ctx context.Context, args []string) error {
+	fs := newFlagSet("get ID")
+	positional, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	menu, err := c.client.GetMenuByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.out.menu(menu)
+}
+
+func (c *command) create(ctx context.Context, args []string) error {
+	fs := newFlagSet("create -name NAME -code CODE [flags]")
+	name := fs.String("name", "", "menu name")
+	code := fs.String("code", "", "unique menu code")
+	parent := fs.Int64("parent", 0, "parent menu ID, omit for a root menu")
+	description := fs.String("description", "", "description")
+	route := fs.String("route", "", "route, starting with /")
+	icon := fs.String("icon", "", "icon name")
+	order := fs.Int("order", 0, "position among its siblings")
+	inactive := fs.Bool("inactive", false, "create the menu disabled")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	req := &domain.CreateMenuRequest{
+		ParentID:    optionalParent(*parent),
+		Name:        *name,
+		Code:        *code,
+		Description: optionalString(*description),
+		Route:       optionalString(*route),
+		Icon:        optionalString(*icon),
+		OrderIndex:  *order,
+		IsActive:    !*inactive,
+	}
+
+	menu, err := c.client.CreateMenu(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.out.menu(menu)
+}
+
+func (c *command) update(ctx context.Context, args []string) error {
+	fs := newFlagSet("update ID [flags]")
+	name := fs.String("name", "", "menu name")
+	code := fs.String("code", "", "unique menu code")
+	description := fs.String("description", "", "description, empty to clear")
+	route := fs.String("route", "", "route, empty to clear")
+	icon := fs.String("icon", "", "icon name, empty to clear")
+	order := fs.Int("order", 0, "position among its siblings")
+	active := fs.Bool("active", true, "whether the menu is enabled")
+	positional, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	// Only the flags given on the command line change the menu
+	req, err := c.currentMenu(ctx, id)
+	if err != nil {
+		return err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			req.Name = *name
+		case "code":
+			req.Code = *code
+		case "description":
+			req.Description = optionalString(*description)
+		case "route":
+			req.Route = optionalString(*route)
+		case "icon":
+			req.Icon = optionalString(*icon)
+		case "order":
+			req.OrderIndex = *order
+		case "active":
+			req.IsActive = *active
+		}
+	})
+
+	menu, err := c.client.UpdateMenu(ctx, id, req)
+	if err != nil {
+		return err
+	}
+	return c.out.menu(menu)
+}
+
+func (c *command) move(ctx context.Context, args []string) error {
+	fs := newFlagSet("move ID (-parent PARENT_ID | -root) [-order N]")
+	parent := fs.Int64("parent", 0, "new parent menu ID")
+	root := fs.Bool("root", false, "move the menu to the root")
+	order := fs.Int("order", 0, "position among its new siblings")
+	positional, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+	if (*parent > 0) == *root {
+		return usagef("move needs exactly one of -parent or -root")
+	}
+
+	req, err := c.currentMenu(ctx, id)
+	if err != nil {
+		return err
+	}
+	req.ParentID = optionalParent(*parent)
+	if isFlagSet(fs, "order") {
+		req.OrderIndex = *order
+	}
+
+	menu, err := c.client.UpdateMenu(ctx, id, req)
+	if err != nil {
+		return err
+	}
+	return c.out.menu(menu)
+}
+
+func (c *command) delete(ctx context.Context, args []string) error {
+	fs := newFlagSet("delete ID")
+	positional, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.DeleteMenu(ctx, id); err != nil {
+		return err
+	}
+	return c.out.message(map[string]any{"id": id, "deleted": true}, "Deleted menu %d", id)
+}
+
+// currentMenu loads a menu as an update request carrying its current values
+func (c *command) currentMenu(ctx context.Context, id int64) (*domain.UpdateMenuRequest, error) {
+	menu, err := c.client.GetMenuByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UpdateMenuRequest{
+		ParentID:    menu.ParentID,
+		Name:        menu.Name,
+		Code:        menu.Code,
+		Description: menu.Description,
+		Route:       menu.Route,
+		Icon:        menu.Icon,
+		OrderIndex:  menu.OrderIndex,
+		IsActive:    menu.IsActive,
+	}, nil
+}
+
+// subtree returns the whole hierarchy, or only the menu named by the optional
+// ID argument together with its descendants
+func (c *command) subtree(ctx context.Context, positional []string) ([]domain.Menu, error) {
+	roots, err := c.client.GetMenuHierarchy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) == 0 {
+		return roots, nil
+	}
+
+	id, err := parseID(positional[0])
+	if err != nil {
+		return nil, err
+	}
+	menu := findMenu(roots, id)
+	if menu == nil {
+		return nil, domain.NewError(domain.ErrNotFound, "menu not found")
+	}
+	return []domain.Menu{*menu}, nil
+}
+
+func findMenu(menus []domain.Menu, id int64) *domain.Menu {
+	for i := range menus {
+		if menus[i].ID == id {
+			return &menus[i]
+		}
+		if found := findMenu(menus[i].Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// newFlagSet creates the flag set of a command. Parse errors are returned
+// rather than printed so that they share the error output of other failures.
+func newFlagSet(synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(synopsis, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: menuctl %s\n", synopsis)
+		fs.SetOutput(os.Stderr)
+		fs.PrintDefaults()
+		fs.SetOutput(io.Discard)
+	}
+	return fs
+}
+
+// parseArgs parses flags placed before, between or after the positional
+// arguments and checks the number of positional arguments
+func parseArgs(fs *flag.FlagSet, args []string, minArgs, maxArgs int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usagef("%v", err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) < minArgs || len(positional) > maxArgs {
+		fs.Usage()
+		return nil, usagef("wrong number of arguments")
+	}
+	return positional, nil
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usagef("invalid menu ID %q", s)
+	}
+	return id, nil
+}
+
+func optionalParent(id int64) *int64 {
+	if id <= 0 {
+		return nil
+	}
+	return &id
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+
+	"stk-technical-test-api/database/migrations"
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/database"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/migration"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/service"
+)
+
+// newDirectClient builds the menu service on the database configured in .env.
+// Changes are written to the outbox as usual, so webhooks still fire, but
+// live subscribers of a running server are not notified.
+func newDirectClient(ctx context.Context) (domain.MenuService, func(), error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := database.NewDatabase(cfg.Database, cfg.Log.SlowQueryThreshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Never write to a schema this build does not know
+	migrator, err := migration.New(db.GetDB(), migrations.FS, cfg.Database.Driver)
+	if err == nil {
+		err = migrator.CheckCurrent(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	menuRepo := repository.NewMenuRepository(db.GetDB())
+	menuService := service.NewMenuService(menuRepo, nil, domain.MenuLimits{
+		MaxDepth:    cfg.Menu.MaxDepth,
+		MaxChildren: cfg.Menu.MaxChildren,
+	})
+
+	return menuService, func() { db.Close() }, nil
+}
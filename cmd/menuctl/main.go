@@ -0,0 +1,167 @@
+// Command menuctl inspects and edits menus from the command line. It talks to
+// a running API over REST or, with -direct, to the database configured in .env.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// Exit codes returned by menuctl. Scripts may rely on them.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitConflict = 4
+	exitInvalid  = 5
+)
+
+const usage = `Usage:
+  menuctl [global flags] COMMAND [flags]
+
+Commands:
+  list                     List all menus
+  tree [ID]                Draw the menu tree, or the subtree under ID
+  get ID                   Show a menu
+  create -name N -code C   Create a menu
+  update ID [flags]        Change the given fields of a menu
+  move ID -parent P|-root  Move a menu under another parent or to the root
+  delete ID                Delete a menu
+  export [ID]              Write the tree, or the subtree under ID, as JSON
+  import FILE              Create the menus of an exported tree ("-" reads stdin)
+
+Global flags:
+  -server URL      API base URL (default $MENUCTL_SERVER or http://localhost:8080)
+  -direct          Use the database configured in .env instead of the API
+  -o FORMAT        Output format: table or json (default table)
+  -timeout D       Timeout for the whole command (default 30s)
+
+Run "menuctl COMMAND -h" for the flags of a command.
+
+Exit codes:
+  0 success, 1 error, 2 usage error, 3 not found,
+  4 conflict (duplicate code or menu has children), 5 invalid input`
+
+// menuClient is the part of domain.MenuService used by menuctl. The service
+// itself is used with -direct and restClient otherwise.
+type menuClient interface {
+	CreateMenu(ctx context.Context, req *domain.CreateMenuRequest) (*domain.Menu, error)
+	UpdateMenu(ctx context.Context, id int64, req *domain.UpdateMenuRequest) (*domain.Menu, error)
+	DeleteMenu(ctx context.Context, id int64) error
+	GetMenuByID(ctx context.Context, id int64) (*domain.Menu, error)
+	GetAllMenus(ctx context.Context) ([]domain.Menu, error)
+	GetMenuHierarchy(ctx context.Context) ([]domain.Menu, error)
+}
+
+// usageError reports a malformed command line
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("menuctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() { fmt.Fprintln(stderr, usage) }
+
+	server := global.String("server", defaultServer(), "API base URL")
+	direct := global.Bool("direct", false, "use the database instead of the API")
+	format := global.String("o", "table", "output format: table or json")
+	timeout := global.Duration("timeout", 30*time.Second, "timeout for the whole command")
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if global.NArg() == 0 {
+		fmt.Fprintln(stderr, usage)
+		return exitUsage
+	}
+
+	out, err := newPrinter(stdout, *format)
+	if err != nil {
+		return fail(err, stderr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var client menuClient
+	if *direct {
+		service, closeDB, err := newDirectClient(ctx)
+		if err != nil {
+			return fail(err, stderr)
+		}
+		defer closeDB()
+		client = service
+	} else {
+		client = newRESTClient(*server)
+	}
+
+	cmd := &command{client: client, out: out, stdin: stdin}
+	return fail(cmd.run(ctx, global.Arg(0), global.Args()[1:]), stderr)
+}
+
+func defaultServer() string {
+	if server := os.Getenv("MENUCTL_SERVER"); server != "" {
+		return server
+	}
+	return "http://localhost:8080"
+}
+
+// fail prints err to stderr and returns the exit code of its kind
+func fail(err error, stderr io.Writer) int {
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	fmt.Fprintln(stderr, "Error:", err)
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		for _, v := range domainErr.Violations {
+			fmt.Fprintf(stderr, "  %s: %s\n", v.Field, v.Message)
+		}
+	}
+
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, domain.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrHasChildren):
+		return exitConflict
+	case errors.Is(err, domain.ErrValidation), errors.Is(err, domain.ErrCycle):
+		return exitInvalid
+	}
+	return exitError
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/service"
+)
+
+// directDatabase points -direct at a migrated SQLite database holding one
+// menu named Direct
+func directDatabase(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "direct.db")
+
+	menus := service.NewMenuService(repository.NewMenuRepository(dbtest.OpenFile(t, path)), nil, domain.MenuLimits{})
+	if _, err := menus.CreateMenu(context.Background(), &domain.CreateMenuRequest{Name: "Direct", Code: "direct"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keep .env and config files of the source tree out of the test
+	t.Chdir(dir)
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("APP_ENV", "test")
+	t.Setenv("DB_DRIVER", config.DriverSQLite)
+	t.Setenv("DB_PATH", path)
+}
+
+func TestRun(t *testing.T) {
+	api := newMenuAPI(t)
+	ctx := context.Background()
+	parent, err := api.service.CreateMenu(ctx, &domain.CreateMenuRequest{Name: "Settings", Code: "settings"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.service.CreateMenu(ctx, &domain.CreateMenuRequest{ParentID: &parent.ID, Name: "Users", Code: "users"}); err != nil {
+		t.Fatal(err)
+	}
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"success": false, "code": "internal_error", "message": "Internal server error"}`))
+	}))
+	defer broken.Close()
+
+	directDatabase(t)
+
+	// rest sends the command to the test API
+	rest := func(args ...string) []string {
+		return append([]string{"-server", api.server.URL}, args...)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		want       int
+		wantStdout string
+		wantStderr string
+	}{
+		{name: "no command", args: nil, want: exitUsage, wantStderr: "Usage:"},
+		{name: "help", args: rest("help"), want: exitOK, wantStdout: "Usage:"},
+		{name: "unknown global flag", args: rest("-verbose", "list"), want: exitUsage},
+		{name: "unknown command", args: rest("rename"), want: exitUsage, wantStderr: "unknown command"},
+		{name: "unknown output format", args: rest("-o", "yaml", "list"), want: exitUsage, wantStderr: "unknown output format"},
+		{name: "missing argument", args: rest("get"), want: exitUsage},
+		{name: "rest", args: rest("get", "1"), want: exitOK, wantStdout: "Settings"},
+		{name: "rest not found", args: rest("get", "999"), want: exitNotFound},
+		{name: "rest duplicate code", args: rest("create", "-name", "Preferences", "-code", "settings"), want: exitConflict},
+		{name: "rest delete with children", args: rest("delete", "1"), want: exitConflict},
+		{name: "rest invalid input", args: rest("create", "-name", "Reports", "-code", "no spaces"), want: exitInvalid, wantStderr: "code:"},
+		{name: "server error", args: []string{"-server", broken.URL, "list"}, want: exitError, wantStderr: "Internal server error"},
+		{name: "direct", args: []string{"-direct", "get", "1"}, want: exitOK, wantStdout: "Direct"},
+		{name: "direct ignores server", args: []string{"-direct", "-server", broken.URL, "list"}, want: exitOK, wantStdout: "Direct"},
+		{name: "direct not found", args: []string{"-direct", "get", "999"}, want: exitNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(""), &stdout, &stderr)
+
+			if code != tt.want {
+				t.Errorf("exit code = %d, want %d\nstderr: %s", code, tt.want, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// printer writes command results as aligned tables or as JSON
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table":
+		return &printer{w: w}, nil
+	case "json":
+		return &printer{w: w, json: true}, nil
+	}
+	return nil, usagef("unknown output format %q, use table or json", format)
+}
+
+func (p *printer) writeJSON(v any) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// menus prints one row per menu
+func (p *printer) menus(menus []domain.Menu) error {
+	if p.json {
+		if menus == nil {
+			menus = []domain.Menu{}
+		}
+		return p.writeJSON(menus)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tPARENT\tLEVEL\tORDER\tCODE\tNAME\tROUTE\tACTIVE")
+	for _, m := range menus {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%s\t%s\t%s\t%t\n",
+			m.ID, optionalID(m.ParentID), m.Level, m.OrderIndex, m.Code, m.Name, optional(m.Route), m.IsActive)
+	}
+	return tw.Flush()
+}
+
+// menu prints a single menu as a list of fields
+func (p *printer) menu(m *domain.Menu) error {
+	if p.json {
+		return p.writeJSON(m)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%d\n", m.ID)
+	fmt.Fprintf(tw, "UUID:\t%s\n", m.UUID)
+	fmt.Fprintf(tw, "Parent:\t%s\n", optionalID(m.ParentID))
+	fmt.Fprintf(tw, "Name:\t%s\n", m.Name)
+	fmt.Fprintf(tw, "Code:\t%s\n", m.Code)
+	fmt.Fprintf(tw, "Description:\t%s\n", optional(m.Description))
+	fmt.Fprintf(tw, "Route:\t%s\n", optional(m.Route))
+	fmt.Fprintf(tw, "Icon:\t%s\n", optional(m.Icon))
+	fmt.Fprintf(tw, "Order:\t%d\n", m.OrderIndex)
+	fmt.Fprintf(tw, "Level:\t%d\n", m.Level)
+	fmt.Fprintf(tw, "Active:\t%t\n", m.IsActive)
+	fmt.Fprintf(tw, "Created:\t%s\n", m.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(tw, "Updated:\t%s\n", m.UpdatedAt.Format("2006-01-02 15:04:05"))
+	return tw.Flush()
+}
+
+// tree draws the menus and their children with box-drawing characters
+func (p *printer) tree(roots []domain.Menu) error {
+	if p.json {
+		if roots == nil {
+			roots = []domain.Menu{}
+		}
+		return p.writeJSON(roots)
+	}
+
+	var b strings.Builder
+	for _, root := range roots {
+		writeNode(&b, root, "", "")
+	}
+	_, err := io.WriteString(p.w, b.String())
+	return err
+}
+
+func writeNode(b *strings.Builder, m domain.Menu, prefix, childPrefix string) {
+	b.WriteString(prefix)
+	b.WriteString(menuLabel(m))
+	b.WriteByte('\n')
+
+	for i, child := range m.Children {
+		if i == len(m.Children)-1 {
+			writeNode(b, child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			writeNode(b, child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+func menuLabel(m domain.Menu) string {
+	label := fmt.Sprintf("%s [%s] #%d", m.Name, m.Code, m.ID)
+	if !m.IsActive {
+		label += " (inactive)"
+	}
+	return label
+}
+
+// message prints a confirmation in table mode and v in JSON mode
+func (p *printer) message(v any, format string, args ...any) error {
+	if p.json {
+		return p.writeJSON(v)
+	}
+	_, err := fmt.Fprintf(p.w, format+"\n", args...)
+	return err
+}
+
+func optional(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
+
+func optionalID(id *int64) string {
+	if id == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*id, 10)
+}
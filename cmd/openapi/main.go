@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"stk-technical-test-api/internal/openapi"
+)
+
+// Generates the OpenAPI document from the handler annotations.
+// Run from the module root or through go generate ./internal/openapi.
+func main() {
+	root := flag.String("root", "", "module root (defaults to the directory containing go.mod)")
+	out := flag.String("out", "internal/openapi/openapi.json", "output file")
+	flag.Parse()
+
+	if *root == "" {
+		dir, err := findModuleRoot()
+		if err != nil {
+			log.Fatal("Failed to find module root:", err)
+		}
+		*root = dir
+	}
+
+	dirs := make([]string, 0, len(openapi.SourceDirs))
+	for _, dir := range openapi.SourceDirs {
+		dirs = append(dirs, filepath.Join(*root, dir))
+	}
+
+	doc, err := openapi.Generate(dirs...)
+	if err != nil {
+		log.Fatal("Failed to generate OpenAPI document:", err)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatal("Failed to encode OpenAPI document:", err)
+	}
+
+	if err := os.WriteFile(*out, append(data, '\n'), 0o644); err != nil {
+		log.Fatal("Failed to write OpenAPI document:", err)
+	}
+	log.Printf("OpenAPI document written to %s", *out)
+}
+
+func findModuleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", os.ErrNotExist
+		}
+		dir = parent
+	}
+}
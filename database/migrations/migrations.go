@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migrations of every supported driver.
+// Files live in one directory per DB_DRIVER value and follow the
+// golang-migrate naming scheme: <version>_<name>.<up|down>.sql.
+package migrations
+
+import "embed"
+
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var FS embed.FS
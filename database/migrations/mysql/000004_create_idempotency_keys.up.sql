@@ -0,0 +1,15 @@
+CREATE TABLE idempotency_keys (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    scope VARCHAR(255) COLLATE utf8mb4_bin NOT NULL,
+    idempotency_key VARCHAR(255) COLLATE utf8mb4_bin NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body MEDIUMTEXT,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    expires_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    UNIQUE KEY idx_idempotency_scope_key (scope, idempotency_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX idx_idempotency_expires_at ON idempotency_keys(expires_at);
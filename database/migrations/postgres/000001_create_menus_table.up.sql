@@ -0,0 +1,22 @@
+CREATE TABLE menus (
+    id BIGSERIAL PRIMARY KEY,
+    parent_id BIGINT NULL,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(100) UNIQUE,
+    description TEXT,
+    route VARCHAR(255),
+    icon VARCHAR(100),
+    order_index INT DEFAULT 0,
+    level INT DEFAULT 0,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_by BIGINT,
+    updated_by BIGINT,
+
+    FOREIGN KEY (parent_id) REFERENCES menus(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_parent_id ON menus(parent_id);
+CREATE INDEX idx_code ON menus(code);
+CREATE INDEX idx_order ON menus(order_index);
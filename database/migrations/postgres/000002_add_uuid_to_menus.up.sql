@@ -0,0 +1,12 @@
+-- Add UUID column (nullable first for existing records)
+ALTER TABLE menus ADD COLUMN uuid VARCHAR(36);
+
+-- Generate UUIDs for existing records
+UPDATE menus SET uuid = gen_random_uuid()::text WHERE uuid IS NULL;
+
+-- Make it NOT NULL and UNIQUE after population
+ALTER TABLE menus ALTER COLUMN uuid SET NOT NULL;
+ALTER TABLE menus ADD CONSTRAINT menus_uuid_key UNIQUE (uuid);
+
+-- Add index for faster lookup
+CREATE INDEX idx_uuid ON menus(uuid);
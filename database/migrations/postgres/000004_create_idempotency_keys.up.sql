@@ -0,0 +1,15 @@
+CREATE TABLE idempotency_keys (
+    id BIGSERIAL PRIMARY KEY,
+    scope VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body TEXT,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    expires_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    CONSTRAINT idx_idempotency_scope_key UNIQUE (scope, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_uuid;
+ALTER TABLE menus DROP COLUMN uuid;
@@ -0,0 +1,15 @@
+-- SQLite cannot add a UNIQUE or NOT NULL column to an existing table, so
+-- uniqueness is enforced by the index below
+ALTER TABLE menus ADD COLUMN uuid VARCHAR(36);
+
+-- Generate version 4 UUIDs for existing records
+UPDATE menus SET uuid = lower(
+    hex(randomblob(4)) || '-' ||
+    hex(randomblob(2)) || '-4' ||
+    substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', 1 + (abs(random()) % 4), 1) ||
+    substr(hex(randomblob(2)), 2) || '-' ||
+    hex(randomblob(6))
+) WHERE uuid IS NULL;
+
+CREATE UNIQUE INDEX idx_uuid ON menus(uuid);
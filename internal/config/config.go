@@ -1,88 +1,441 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"net"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	App      AppConfig
-	CORS     CORSConfig
+	App         AppConfig         `yaml:"app"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Server      ServerConfig      `yaml:"server"`
+	TLS         TLSConfig         `yaml:"tls"`
+	GRPC        GRPCConfig        `yaml:"grpc"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Menu        MenuConfig        `yaml:"menu"`
+	Events      EventsConfig      `yaml:"events"`
+	Webhook     WebhookConfig     `yaml:"webhook"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	Log         LogConfig         `yaml:"log"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+
+	// Files lists the config files that were merged, in order
+	Files []string `yaml:"-"`
 }
 
+// Supported values of DB_DRIVER
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	DBName   string
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	DBName   string `yaml:"name"`
+	SSLMode  string `yaml:"sslmode"`
+	Path     string `yaml:"path"`
+
+	AutoMigrate bool `yaml:"auto_migrate"`
+
+	// Connection pool
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+
+	// Startup retry: ConnectAttempts tries, waiting ConnectBackoff after the
+	// first failure and twice as long after each further one
+	ConnectAttempts int           `yaml:"connect_attempts"`
+	ConnectBackoff  time.Duration `yaml:"connect_backoff"`
+
+	// MySQL TLS: TLSMode is false, true (verify the server), skip-verify or
+	// preferred. A CA file replaces the system roots; a client certificate
+	// and key enable mutual TLS.
+	TLSMode       string `yaml:"tls_mode"`
+	TLSCAFile     string `yaml:"tls_ca_file"`
+	TLSCertFile   string `yaml:"tls_cert_file"`
+	TLSKeyFile    string `yaml:"tls_key_file"`
+	TLSServerName string `yaml:"tls_server_name"`
+}
+
+// Supported values of DB_TLS_MODE
+const (
+	DBTLSDisabled   = "false"
+	DBTLSVerify     = "true"
+	DBTLSSkipVerify = "skip-verify"
+	DBTLSPreferred  = "preferred"
+)
+
+// MySQLTLSConfigName is the name under which the database package registers
+// the custom TLS configuration referenced by the MySQL DSN
+const MySQLTLSConfigName = "stk-custom"
+
+// CustomTLS reports whether the MySQL connection needs a registered TLS
+// configuration rather than one of the driver's built-in modes
+func (d DatabaseConfig) CustomTLS() bool {
+	return (d.TLSMode == DBTLSVerify || d.TLSMode == DBTLSSkipVerify) &&
+		(d.TLSCAFile != "" || d.TLSCertFile != "" || d.TLSServerName != "")
 }
 
 type ServerConfig struct {
-	Port string
+	Port              string        `yaml:"port"`
+	RequestTimeout    time.Duration `yaml:"request_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes"`
+	// ShutdownTimeout is the grace period for in-flight requests on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ReadinessTimeout bounds the dependency checks behind /readyz
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout"`
+	// MaxBodyBytes caps request bodies; zero means unlimited
+	MaxBodyBytes int64 `yaml:"max_body_bytes"`
+	// TrustedProxies may set X-Forwarded-For; the client IP of requests
+	// from any other address is the connection's remote address
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// TLSConfig serves the HTTP, gRPC and metrics ports over TLS with the
+// certificate in CertFile and KeyFile, reloaded every ReloadInterval when the
+// files change. HTTP3 additionally serves HTTP/3 on the UDP port of the API.
+type TLSConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	CertFile       string        `yaml:"cert_file"`
+	KeyFile        string        `yaml:"key_file"`
+	MinVersion     string        `yaml:"min_version"`
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+	HTTP3          bool          `yaml:"http3"`
+}
+
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Port    string `yaml:"port"`
 }
 
 type AppConfig struct {
-	Env string
+	Env string `yaml:"env"`
 }
 
 type CORSConfig struct {
-	AllowedOrigins []string
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
+}
+
+type MenuConfig struct {
+	MaxDepth    int `yaml:"max_depth"`
+	MaxChildren int `yaml:"max_children"`
+}
+
+// MetricsConfig controls the Prometheus endpoint. With an empty Port the
+// metrics are served on the API port, otherwise on a separate admin server.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Port    string `yaml:"port"`
 }
 
-func LoadConfig() *Config {
-	// Load .env file
-	err := godotenv.Load()
-	if err != nil {
-		log.Println("Warning: .env file not found, using system environment variables")
+// Supported values of TRACING_EXPORTER
+const (
+	TracingNone   = "none"
+	TracingOTLP   = "otlp"
+	TracingStdout = "stdout"
+)
+
+// TracingConfig selects where OpenTelemetry spans are exported. The OTLP
+// endpoint and headers come from the standard OTEL_EXPORTER_OTLP_* variables.
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter"`
+	OTLPProtocol string `yaml:"otlp_protocol"`
+	// File receives the spans of the stdout exporter; empty means stdout
+	File        string  `yaml:"file"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+	ServiceName string  `yaml:"service_name"`
+}
+
+// Enabled reports whether spans are exported at all
+func (t TracingConfig) Enabled() bool {
+	return t.Exporter != TracingNone
+}
+
+// Supported values of LOG_FORMAT
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// LogConfig controls the application log. Queries slower than
+// SlowQueryThreshold are logged as warnings; zero disables the check.
+type LogConfig struct {
+	Level              string        `yaml:"level"`
+	Format             string        `yaml:"format"`
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold"`
+}
+
+// RateLimitConfig sets the request budgets of each client: Reads safe
+// requests and Writes other requests per Window
+type RateLimitConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Reads   int           `yaml:"reads"`
+	Writes  int           `yaml:"writes"`
+	Window  time.Duration `yaml:"window"`
+}
+
+// IdempotencyConfig keeps the responses to requests sent with an
+// Idempotency-Key for TTL
+type IdempotencyConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	TTL     time.Duration `yaml:"ttl"`
+}
+
+type EventsConfig struct {
+	BufferSize int `yaml:"buffer_size"`
+}
+
+type WebhookConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	MaxAttempts  int           `yaml:"max_attempts"`
+	BaseBackoff  time.Duration `yaml:"base_backoff"`
+	MaxBackoff   time.Duration `yaml:"max_backoff"`
+	Timeout      time.Duration `yaml:"timeout"`
+	// Retention is how long delivered outbox events are kept; 0 keeps them
+	Retention        time.Duration `yaml:"retention"`
+	AllowPrivateURLs bool          `yaml:"allow_private_urls"`
+}
+
+// LoadConfig merges, in increasing precedence, the built-in defaults, the
+// config file, its overlay for the current environment and the environment
+// variables (including .env), then validates the result. When only the
+// validation fails the merged config is returned along with a
+// *ValidationError, so it can still be inspected.
+func LoadConfig() (*Config, error) {
+	// A missing .env file is normal outside local development
+	_ = godotenv.Load()
+
+	cfg := defaultConfig()
+	if err := cfg.loadFiles(); err != nil {
+		return nil, err
 	}
 
+	env := &envSource{}
+	env.apply(cfg)
+	cfg.normalize()
+
+	problems := append(env.problems, cfg.problems()...)
+	if len(problems) > 0 {
+		return cfg, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// defaultConfig returns the settings used when nothing else is configured
+func defaultConfig() *Config {
 	return &Config{
+		App: AppConfig{
+			Env: "development",
+		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "127.0.0.1"),
-			Port:     getEnv("DB_PORT", "3306"),
-			User:     getEnv("DB_USER", "root"),
-			Password: getEnv("DB_PASSWORD", ""),
-			DBName:   getEnv("DB_NAME", "stk_menu_system"),
+			Driver:  DriverMySQL,
+			Host:    "127.0.0.1",
+			DBName:  "stk_menu_system",
+			SSLMode: "disable",
+			Path:    "stk_menu_system.db",
+
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+
+			ConnectAttempts: 5,
+			ConnectBackoff:  time.Second,
+
+			TLSMode: DBTLSDisabled,
 		},
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
+			Port:              "8080",
+			RequestTimeout:    30 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   10 * time.Second,
+			ReadinessTimeout:  2 * time.Second,
+			MaxBodyBytes:      1 << 20,
 		},
-		App: AppConfig{
-			Env: getEnv("APP_ENV", "development"),
+		TLS: TLSConfig{
+			MinVersion:     "1.2",
+			ReloadInterval: 10 * time.Second,
+		},
+		GRPC: GRPCConfig{
+			Enabled: true,
+			Port:    "9090",
 		},
 		CORS: CORSConfig{
-			AllowedOrigins: getCORSOrigins(),
+			AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:3001", "http://localhost:5173"},
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization", "Last-Event-ID", "X-Request-ID", "Idempotency-Key"},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+		},
+		Menu: MenuConfig{
+			MaxDepth:    10,
+			MaxChildren: 100,
+		},
+		Events: EventsConfig{
+			BufferSize: 1000,
+		},
+		Webhook: WebhookConfig{
+			Enabled:      true,
+			PollInterval: 2 * time.Second,
+			BatchSize:    50,
+			MaxAttempts:  8,
+			BaseBackoff:  5 * time.Second,
+			MaxBackoff:   time.Hour,
+			Timeout:      10 * time.Second,
+			Retention:    7 * 24 * time.Hour,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:     TracingNone,
+			OTLPProtocol: "grpc",
+			SampleRatio:  1,
+			ServiceName:  "stk-menu-api",
+		},
+		Log: LogConfig{
+			Format:             LogFormatJSON,
+			SlowQueryThreshold: 200 * time.Millisecond,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Reads:   300,
+			Writes:  60,
+			Window:  time.Minute,
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled: true,
+			TTL:     24 * time.Hour,
 		},
 	}
 }
 
+// normalize lowercases enumerated settings and fills in the defaults that
+// depend on other settings
+func (c *Config) normalize() {
+	for _, s := range []*string{
+		&c.Database.Driver,
+		&c.Database.TLSMode,
+		&c.Tracing.Exporter,
+		&c.Tracing.OTLPProtocol,
+		&c.Log.Level,
+		&c.Log.Format,
+	} {
+		*s = strings.ToLower(strings.TrimSpace(*s))
+	}
+
+	if c.Database.Port == "" {
+		c.Database.Port = defaultDBPort(c.Database.Driver)
+	}
+	if c.Log.Level == "" {
+		// Log every SQL statement in development
+		c.Log.Level = "info"
+		if c.App.Env == "development" {
+			c.Log.Level = "debug"
+		}
+	}
+}
+
+// ValidationError lists every invalid setting found at startup
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	msg := "invalid configuration:"
+	for _, p := range e.Problems {
+		msg += "\n  - " + p
+	}
+	return msg
+}
+
+// IsValidationError reports whether err means the configuration was loaded
+// but is invalid
+func IsValidationError(err error) bool {
+	var validationErr *ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// GetDSN builds the connection string for the configured driver
 func (c *Config) GetDSN() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		c.Database.User,
-		c.Database.Password,
-		c.Database.Host,
-		c.Database.Port,
-		c.Database.DBName,
+	return c.Database.DSN()
+}
+
+// DSN builds the connection string for the configured driver
+func (d DatabaseConfig) DSN() string {
+	switch d.Driver {
+	case DriverPostgres:
+		return d.postgresDSN()
+	case DriverSQLite:
+		return d.sqliteDSN()
+	default:
+		return d.mysqlDSN()
+	}
+}
+
+func (d DatabaseConfig) mysqlDSN() string {
+	tlsParam := d.TLSMode
+	if d.CustomTLS() {
+		tlsParam = MySQLTLSConfigName
+	}
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&tls=%s",
+		d.User,
+		d.Password,
+		d.Host,
+		d.Port,
+		d.DBName,
+		url.QueryEscape(tlsParam),
 	)
 }
 
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+func (d DatabaseConfig) postgresDSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     net.JoinHostPort(d.Host, d.Port),
+		Path:     "/" + d.DBName,
+		RawQuery: url.Values{"sslmode": {d.SSLMode}}.Encode(),
 	}
-	return value
+	return dsn.String()
 }
 
-func getCORSOrigins() []string {
-	origins := getEnv("ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:3001,http://localhost:5173")
-	return strings.Split(origins, ",")
+func (d DatabaseConfig) sqliteDSN() string {
+	// Foreign keys are off by default in SQLite; the busy timeout lets
+	// concurrent writers wait for the lock instead of failing
+	return "file:" + d.Path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
 }
 
+func defaultDBPort(driver string) string {
+	if driver == DriverPostgres {
+		return "5432"
+	}
+	return "3306"
+}
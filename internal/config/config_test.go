@@ -0,0 +1,235 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// inTempDir runs the test in an empty directory with a SQLite database, so
+// only the files and variables set by the test are loaded
+func inTempDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, key := range []string{"CONFIG_FILE", "APP_ENV"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	t.Setenv("DB_DRIVER", DriverSQLite)
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	inTempDir(t)
+	writeFile(t, "config.yaml", `
+app:
+  env: staging
+server:
+  port: "8081"
+  request_timeout: 10s
+  read_timeout: 11s
+menu:
+  max_depth: 5
+`)
+	writeFile(t, "config.staging.yaml", `
+server:
+  read_timeout: 12s
+menu:
+  max_depth: 6
+`)
+	t.Setenv("MENU_MAX_DEPTH", "7")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "default", got: cfg.Server.WriteTimeout, want: 60 * time.Second},
+		{name: "file", got: cfg.Server.Port, want: "8081"},
+		{name: "file over default", got: cfg.Server.RequestTimeout, want: 10 * time.Second},
+		{name: "overlay over file", got: cfg.Server.ReadTimeout, want: 12 * time.Second},
+		{name: "env over overlay", got: cfg.Menu.MaxDepth, want: 7},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if want := []string{"config.yaml", "config.staging.yaml"}; !slices.Equal(cfg.Files, want) {
+		t.Errorf("files = %q, want %q", cfg.Files, want)
+	}
+}
+
+func TestLoadConfigOverlayFromAppEnv(t *testing.T) {
+	dir := inTempDir(t)
+	base := filepath.Join(dir, "settings.yaml")
+	writeFile(t, base, "app:\n  env: staging\n")
+	writeFile(t, filepath.Join(dir, "settings.staging.yaml"), "menu:\n  max_depth: 6\n")
+	writeFile(t, filepath.Join(dir, "settings.production.yaml"), "menu:\n  max_depth: 8\n")
+	t.Setenv("CONFIG_FILE", base)
+	t.Setenv("APP_ENV", "production")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Menu.MaxDepth != 8 || cfg.App.Env != "production" {
+		t.Errorf("max depth %d in %s, want the production overlay", cfg.Menu.MaxDepth, cfg.App.Env)
+	}
+}
+
+func TestLoadConfigWithoutEnvSkipsOverlay(t *testing.T) {
+	inTempDir(t)
+	writeFile(t, "config.yaml", "app:\n  env: \"\"\n")
+	writeFile(t, "config..yaml", "not: [valid")
+
+	cfg, err := LoadConfig()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || !slices.Contains(invalid.Problems, "app.env (APP_ENV): is required") {
+		t.Fatalf("err = %v, want app.env to be required", err)
+	}
+	if !slices.Equal(cfg.Files, []string{"config.yaml"}) {
+		t.Errorf("files = %q, want only config.yaml", cfg.Files)
+	}
+}
+
+func TestLoadConfigRejectsUnknownKeys(t *testing.T) {
+	inTempDir(t)
+	writeFile(t, "config.yaml", "server:\n  prot: \"8081\"\n")
+
+	_, err := LoadConfig()
+	if err == nil || IsValidationError(err) || !strings.Contains(err.Error(), "prot") {
+		t.Errorf("err = %v, want the unknown key to be rejected", err)
+	}
+}
+
+func TestLoadConfigTOML(t *testing.T) {
+	dir := inTempDir(t)
+	path := filepath.Join(dir, "config.toml")
+	writeFile(t, path, `
+[server]
+request_timeout = "45s"
+trusted_proxies = ["10.0.0.0/8"]
+
+[database]
+connect_backoff = "1m30s"
+max_open_conns = 3
+`)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.RequestTimeout != 45*time.Second || cfg.Database.ConnectBackoff != 90*time.Second {
+		t.Errorf("durations = %s, %s, want 45s and 1m30s", cfg.Server.RequestTimeout, cfg.Database.ConnectBackoff)
+	}
+	if cfg.Database.MaxOpenConns != 3 || !slices.Equal(cfg.Server.TrustedProxies, []string{"10.0.0.0/8"}) {
+		t.Errorf("max open conns %d, trusted proxies %q", cfg.Database.MaxOpenConns, cfg.Server.TrustedProxies)
+	}
+	if !slices.Equal(cfg.Files, []string{"config.toml"}) {
+		t.Errorf("files = %q, want config.toml", cfg.Files)
+	}
+}
+
+func TestLoadConfigReportsMalformedEnv(t *testing.T) {
+	inTempDir(t)
+	t.Setenv("SERVER_REQUEST_TIMEOUT", "soon")
+
+	_, err := LoadConfig()
+	var invalid *ValidationError
+	want := `SERVER_REQUEST_TIMEOUT: "soon" is not a duration such as 30s or 5m`
+	if !errors.As(err, &invalid) || !slices.Contains(invalid.Problems, want) {
+		t.Errorf("err = %v, want %q", err, want)
+	}
+}
+
+func TestProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{
+			name:   "missing env",
+			modify: func(c *Config) { c.App.Env = "" },
+			want:   "app.env (APP_ENV): is required",
+		},
+		{
+			name:   "unknown driver",
+			modify: func(c *Config) { c.Database.Driver = "oracle" },
+			want:   `database.driver (DB_DRIVER): must be one of [mysql postgres sqlite], got "oracle"`,
+		},
+		{
+			name:   "production without password",
+			modify: func(c *Config) { c.App.Env = "production"; c.Database.Driver = DriverPostgres; c.Database.User = "app" },
+			want:   "database.password (DB_PASSWORD): is required in production",
+		},
+		{
+			name:   "zero shutdown timeout",
+			modify: func(c *Config) { c.Server.ShutdownTimeout = 0 },
+			want:   "server.shutdown_timeout (SERVER_SHUTDOWN_TIMEOUT): must be positive, got 0s",
+		},
+		{
+			name:   "invalid port",
+			modify: func(c *Config) { c.Server.Port = "http" },
+			want:   `server.port (SERVER_PORT): must be a port number between 1 and 65535, got "http"`,
+		},
+		{
+			name:   "invalid trusted proxy",
+			modify: func(c *Config) { c.Server.TrustedProxies = []string{"gateway"} },
+			want:   `server.trusted_proxies (SERVER_TRUSTED_PROXIES): "gateway" is not an IP address or CIDR range`,
+		},
+		{
+			name:   "http3 without tls",
+			modify: func(c *Config) { c.TLS.HTTP3 = true },
+			want:   "tls.http3 (TLS_HTTP3): requires TLS to be enabled",
+		},
+		{
+			name:   "grpc on the http port",
+			modify: func(c *Config) { c.GRPC.Port = c.Server.Port },
+			want:   "grpc.port (GRPC_PORT): must differ from server.port",
+		},
+		{
+			name:   "origin without scheme",
+			modify: func(c *Config) { c.CORS.AllowedOrigins = []string{"localhost:3000"} },
+			want:   `cors.allowed_origins (ALLOWED_ORIGINS): "localhost:3000" must be an http or https origin such as https://app.example.com`,
+		},
+	}
+
+	valid := defaultConfig()
+	valid.Database.Driver = DriverSQLite
+	valid.normalize()
+	if problems := valid.problems(); len(problems) != 0 {
+		t.Fatalf("default SQLite config has problems: %q", problems)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultConfig()
+			c.Database.Driver = DriverSQLite
+			tt.modify(c)
+			c.normalize()
+
+			if problems := c.problems(); !slices.Contains(problems, tt.want) {
+				t.Errorf("problems = %q, want %q", problems, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envSource overrides settings from environment variables. Any variable KEY
+// can instead be given as KEY_FILE, naming a file that holds the value, so
+// secrets mounted by Docker or Kubernetes need not live in the environment.
+// Malformed values are collected as problems rather than silently replaced
+// by defaults.
+type envSource struct {
+	problems []string
+}
+
+// apply overrides every setting whose variable is set
+func (e *envSource) apply(c *Config) {
+	e.string("APP_ENV", &c.App.Env)
+
+	d := &c.Database
+	e.string("DB_DRIVER", &d.Driver)
+	e.string("DB_HOST", &d.Host)
+	e.string("DB_PORT", &d.Port)
+	e.string("DB_USER", &d.User)
+	e.string("DB_PASSWORD", &d.Password)
+	e.string("DB_NAME", &d.DBName)
+	e.string("DB_SSLMODE", &d.SSLMode)
+	e.string("DB_PATH", &d.Path)
+	e.bool("DB_AUTO_MIGRATE", &d.AutoMigrate)
+	e.int("DB_MAX_OPEN_CONNS", &d.MaxOpenConns)
+	e.int("DB_MAX_IDLE_CONNS", &d.MaxIdleConns)
+	e.duration("DB_CONN_MAX_LIFETIME", &d.ConnMaxLifetime)
+	e.duration("DB_CONN_MAX_IDLE_TIME", &d.ConnMaxIdleTime)
+	e.int("DB_CONNECT_ATTEMPTS", &d.ConnectAttempts)
+	e.duration("DB_CONNECT_BACKOFF", &d.ConnectBackoff)
+	e.string("DB_TLS_MODE", &d.TLSMode)
+	e.string("DB_TLS_CA_FILE", &d.TLSCAFile)
+	e.string("DB_TLS_CERT_FILE", &d.TLSCertFile)
+	e.string("DB_TLS_KEY_FILE", &d.TLSKeyFile)
+	e.string("DB_TLS_SERVER_NAME", &d.TLSServerName)
+
+	s := &c.Server
+	e.string("SERVER_PORT", &s.Port)
+	e.duration("SERVER_REQUEST_TIMEOUT", &s.RequestTimeout)
+	e.duration("SERVER_READ_TIMEOUT", &s.ReadTimeout)
+	e.duration("SERVER_READ_HEADER_TIMEOUT", &s.ReadHeaderTimeout)
+	e.duration("SERVER_WRITE_TIMEOUT", &s.WriteTimeout)
+	e.duration("SERVER_IDLE_TIMEOUT", &s.IdleTimeout)
+	e.int("SERVER_MAX_HEADER_BYTES", &s.MaxHeaderBytes)
+	e.duration("SERVER_SHUTDOWN_TIMEOUT", &s.ShutdownTimeout)
+	e.duration("SERVER_READINESS_TIMEOUT", &s.ReadinessTimeout)
+	e.int64("SERVER_MAX_BODY_BYTES", &s.MaxBodyBytes)
+	e.list("SERVER_TRUSTED_PROXIES", &s.TrustedProxies)
+
+	e.bool("TLS_ENABLED", &c.TLS.Enabled)
+	e.string("TLS_CERT_FILE", &c.TLS.CertFile)
+	e.string("TLS_KEY_FILE", &c.TLS.KeyFile)
+	e.string("TLS_MIN_VERSION", &c.TLS.MinVersion)
+	e.duration("TLS_RELOAD_INTERVAL", &c.TLS.ReloadInterval)
+	e.bool("TLS_HTTP3", &c.TLS.HTTP3)
+
+	e.bool("GRPC_ENABLED", &c.GRPC.Enabled)
+	e.string("GRPC_PORT", &c.GRPC.Port)
+
+	e.list("ALLOWED_ORIGINS", &c.CORS.AllowedOrigins)
+	e.list("CORS_ALLOWED_METHODS", &c.CORS.AllowedMethods)
+	e.list("CORS_ALLOWED_HEADERS", &c.CORS.AllowedHeaders)
+	e.bool("CORS_ALLOW_CREDENTIALS", &c.CORS.AllowCredentials)
+	e.duration("CORS_MAX_AGE", &c.CORS.MaxAge)
+
+	e.int("MENU_MAX_DEPTH", &c.Menu.MaxDepth)
+	e.int("MENU_MAX_CHILDREN", &c.Menu.MaxChildren)
+
+	e.int("EVENTS_BUFFER_SIZE", &c.Events.BufferSize)
+
+	w := &c.Webhook
+	e.bool("WEBHOOK_WORKER_ENABLED", &w.Enabled)
+	e.duration("WEBHOOK_POLL_INTERVAL", &w.PollInterval)
+	e.int("WEBHOOK_BATCH_SIZE", &w.BatchSize)
+	e.int("WEBHOOK_MAX_ATTEMPTS", &w.MaxAttempts)
+	e.duration("WEBHOOK_BASE_BACKOFF", &w.BaseBackoff)
+	e.duration("WEBHOOK_MAX_BACKOFF", &w.MaxBackoff)
+	e.duration("WEBHOOK_TIMEOUT", &w.Timeout)
+	e.duration("WEBHOOK_RETENTION", &w.Retention)
+	e.bool("WEBHOOK_ALLOW_PRIVATE_URLS", &w.AllowPrivateURLs)
+
+	e.bool("METRICS_ENABLED", &c.Metrics.Enabled)
+	e.string("METRICS_PORT", &c.Metrics.Port)
+
+	t := &c.Tracing
+	e.string("TRACING_EXPORTER", &t.Exporter)
+	e.string("TRACING_OTLP_PROTOCOL", &t.OTLPProtocol)
+	e.string("TRACING_FILE", &t.File)
+	e.float("TRACING_SAMPLE_RATIO", &t.SampleRatio)
+	e.string("TRACING_SERVICE_NAME", &t.ServiceName)
+
+	e.string("LOG_LEVEL", &c.Log.Level)
+	e.string("LOG_FORMAT", &c.Log.Format)
+	e.duration("LOG_SLOW_QUERY_THRESHOLD", &c.Log.SlowQueryThreshold)
+
+	r := &c.RateLimit
+	e.bool("RATE_LIMIT_ENABLED", &r.Enabled)
+	e.int("RATE_LIMIT_READS", &r.Reads)
+	e.int("RATE_LIMIT_WRITES", &r.Writes)
+	e.duration("RATE_LIMIT_WINDOW", &r.Window)
+
+	e.bool("IDEMPOTENCY_ENABLED", &c.Idempotency.Enabled)
+	e.duration("IDEMPOTENCY_TTL", &c.Idempotency.TTL)
+}
+
+// lookup returns the value of key, read from the file named by key_FILE
+// when key itself is unset
+func (e *envSource) lookup(key string) (string, bool) {
+	value := os.Getenv(key)
+	path := os.Getenv(key + "_FILE")
+
+	switch {
+	case value != "" && path != "":
+		e.problemf("%s and %s_FILE are both set; use only one", key, key)
+		return "", false
+	case value != "":
+		return value, true
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			e.problemf("%s_FILE: %v", key, err)
+			return "", false
+		}
+		// Editors and secret stores often add a final newline
+		return strings.TrimRight(string(data), "\r\n"), true
+	}
+	return "", false
+}
+
+func (e *envSource) problemf(format string, args ...any) {
+	e.problems = append(e.problems, fmt.Sprintf(format, args...))
+}
+
+func (e *envSource) string(key string, dst *string) {
+	if value, ok := e.lookup(key); ok {
+		*dst = value
+	}
+}
+
+func (e *envSource) int(key string, dst *int) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.problemf("%s: %q is not an integer", key, value)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envSource) int64(key string, dst *int64) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		e.problemf("%s: %q is not an integer", key, value)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envSource) float(key string, dst *float64) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.problemf("%s: %q is not a number", key, value)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envSource) bool(key string, dst *bool) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.problemf("%s: %q is not a boolean, use true or false", key, value)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envSource) duration(key string, dst *time.Duration) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.problemf("%s: %q is not a duration such as 30s or 5m", key, value)
+		return
+	}
+	*dst = parsed
+}
+
+// list splits a comma separated value, ignoring blank entries
+func (e *envSource) list(key string, dst *[]string) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	*dst = values
+}
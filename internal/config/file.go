@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// configExtensions are the supported config file formats, in lookup order
+var configExtensions = []string{".yaml", ".yml", ".toml"}
+
+// loadFiles merges the config file and its environment overlay into c. The
+// file is CONFIG_FILE, or config.yaml, config.yml or config.toml in the
+// working directory; none is required. The overlay is <name>.<env>.<ext> next
+// to it, e.g. config.production.yaml, where env is APP_ENV or else app.env
+// from the file; there is no overlay when both are empty.
+func (c *Config) loadFiles() error {
+	base := os.Getenv("CONFIG_FILE")
+	if base != "" {
+		if err := c.decodeFile(base); err != nil {
+			return err
+		}
+	} else {
+		found, err := c.decodeFirst(".", "config")
+		if err != nil {
+			return err
+		}
+		base = found
+	}
+
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = c.App.Env
+	}
+	if env == "" {
+		return nil
+	}
+	dir, name := ".", "config"
+	if base != "" {
+		dir = filepath.Dir(base)
+		name = strings.TrimSuffix(filepath.Base(base), filepath.Ext(base))
+	}
+	_, err := c.decodeFirst(dir, name+"."+env)
+	return err
+}
+
+// decodeFirst decodes dir/name with the first supported extension that
+// exists and returns its path, or "" when there is none
+func (c *Config) decodeFirst(dir, name string) (string, error) {
+	for _, ext := range configExtensions {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		return path, c.decodeFile(path)
+	}
+	return "", nil
+}
+
+// decodeFile merges the file at path into c. Settings the file does not
+// mention keep their value; unknown keys are rejected so typos are caught.
+func (c *Config) decodeFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	case ".toml":
+		// TOML has no duration type; converting to YAML lets both formats
+		// share one decoder that accepts values such as "30s"
+		var doc map[string]any
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		if data, err = yaml.Marshal(doc); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	c.Files = append(c.Files, path)
+	return nil
+}
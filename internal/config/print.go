@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets in printed configurations
+const redacted = "[REDACTED]"
+
+// Redacted returns a copy of c with secrets replaced, safe to print or log
+func (c *Config) Redacted() *Config {
+	clone := *c
+	if clone.Database.Password != "" {
+		clone.Database.Password = redacted
+	}
+	return &clone
+}
+
+// Encode writes c in the config file format, "yaml" or "toml", so the
+// output can be used as a config file
+func (c *Config) Encode(w io.Writer, format string) error {
+	switch format {
+	case "yaml":
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(c); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "toml":
+		// Go through YAML so durations are written as "30s" like the
+		// files are read
+		data, err := yaml.Marshal(c)
+		if err != nil {
+			return err
+		}
+		var doc map[string]any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		return toml.NewEncoder(w).Encode(doc)
+	}
+	return fmt.Errorf("unsupported format %q, use yaml or toml", format)
+}
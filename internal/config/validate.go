@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// validator collects the problems of a configuration. Settings are named by
+// their config file key followed by the environment variable.
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, key, env, format string, args ...any) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf("%s (%s): %s", key, env, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (v *validator) positive(d time.Duration, key, env string) {
+	v.check(d > 0, key, env, "must be positive, got %s", d)
+}
+
+func (v *validator) nonNegative(d time.Duration, key, env string) {
+	v.check(d >= 0, key, env, "must not be negative, got %s", d)
+}
+
+func (v *validator) port(port, key, env string) {
+	n, err := strconv.Atoi(port)
+	v.check(err == nil && n > 0 && n <= 65535, key, env, "must be a port number between 1 and 65535, got %q", port)
+}
+
+// file checks that an optional path names a readable file
+func (v *validator) file(path, key, env string) {
+	if path == "" {
+		return
+	}
+	f, err := os.Open(path)
+	if err == nil {
+		f.Close()
+	}
+	v.check(err == nil, key, env, "%v", err)
+}
+
+func (v *validator) oneOf(value string, allowed []string, key, env string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, key, env, "must be one of %v, got %q", allowed, value)
+}
+
+// problems validates every setting and returns what is wrong with them
+func (c *Config) problems() []string {
+	v := &validator{}
+
+	v.check(c.App.Env != "", "app.env", "APP_ENV", "is required")
+	production := c.App.Env == "production"
+
+	d := c.Database
+	v.oneOf(d.Driver, []string{DriverMySQL, DriverPostgres, DriverSQLite}, "database.driver", "DB_DRIVER")
+	if d.Driver == DriverSQLite {
+		v.check(d.Path != "", "database.path", "DB_PATH", "is required for sqlite")
+	} else {
+		v.check(d.Host != "", "database.host", "DB_HOST", "is required for %s", d.Driver)
+		v.port(d.Port, "database.port", "DB_PORT")
+		v.check(d.User != "", "database.user", "DB_USER", "is required for %s", d.Driver)
+		v.check(d.DBName != "", "database.name", "DB_NAME", "is required for %s", d.Driver)
+		v.check(d.Password != "" || !production, "database.password", "DB_PASSWORD", "is required in production")
+	}
+	v.check(d.MaxOpenConns >= 0, "database.max_open_conns", "DB_MAX_OPEN_CONNS", "must not be negative")
+	v.check(d.MaxIdleConns >= 0, "database.max_idle_conns", "DB_MAX_IDLE_CONNS", "must not be negative")
+	v.nonNegative(d.ConnMaxLifetime, "database.conn_max_lifetime", "DB_CONN_MAX_LIFETIME")
+	v.nonNegative(d.ConnMaxIdleTime, "database.conn_max_idle_time", "DB_CONN_MAX_IDLE_TIME")
+	v.check(d.ConnectAttempts >= 1, "database.connect_attempts", "DB_CONNECT_ATTEMPTS", "must be at least 1")
+	v.nonNegative(d.ConnectBackoff, "database.connect_backoff", "DB_CONNECT_BACKOFF")
+	if d.Driver == DriverMySQL {
+		v.oneOf(d.TLSMode, []string{DBTLSDisabled, DBTLSVerify, DBTLSSkipVerify, DBTLSPreferred}, "database.tls_mode", "DB_TLS_MODE")
+		custom := d.TLSCAFile != "" || d.TLSCertFile != "" || d.TLSKeyFile != "" || d.TLSServerName != ""
+		v.check(!custom || d.TLSMode == DBTLSVerify || d.TLSMode == DBTLSSkipVerify, "database.tls_mode", "DB_TLS_MODE",
+			"must be true or skip-verify when a TLS CA, certificate or server name is set")
+		v.check((d.TLSCertFile == "") == (d.TLSKeyFile == ""), "database.tls_cert_file", "DB_TLS_CERT_FILE",
+			"must be set together with database.tls_key_file")
+		v.file(d.TLSCAFile, "database.tls_ca_file", "DB_TLS_CA_FILE")
+		v.file(d.TLSCertFile, "database.tls_cert_file", "DB_TLS_CERT_FILE")
+		v.file(d.TLSKeyFile, "database.tls_key_file", "DB_TLS_KEY_FILE")
+	} else {
+		v.check(d.TLSMode == DBTLSDisabled && d.TLSCAFile == "" && d.TLSCertFile == "" && d.TLSKeyFile == "" && d.TLSServerName == "",
+			"database.tls_mode", "DB_TLS_MODE", "is only supported for mysql; use database.sslmode for postgres")
+	}
+
+	s := c.Server
+	v.port(s.Port, "server.port", "SERVER_PORT")
+	v.nonNegative(s.RequestTimeout, "server.request_timeout", "SERVER_REQUEST_TIMEOUT")
+	v.nonNegative(s.ReadTimeout, "server.read_timeout", "SERVER_READ_TIMEOUT")
+	v.nonNegative(s.ReadHeaderTimeout, "server.read_header_timeout", "SERVER_READ_HEADER_TIMEOUT")
+	v.nonNegative(s.WriteTimeout, "server.write_timeout", "SERVER_WRITE_TIMEOUT")
+	v.nonNegative(s.IdleTimeout, "server.idle_timeout", "SERVER_IDLE_TIMEOUT")
+	v.check(s.MaxHeaderBytes >= 0, "server.max_header_bytes", "SERVER_MAX_HEADER_BYTES", "must not be negative")
+	v.positive(s.ShutdownTimeout, "server.shutdown_timeout", "SERVER_SHUTDOWN_TIMEOUT")
+	v.positive(s.ReadinessTimeout, "server.readiness_timeout", "SERVER_READINESS_TIMEOUT")
+	v.check(s.MaxBodyBytes >= 0, "server.max_body_bytes", "SERVER_MAX_BODY_BYTES", "must not be negative")
+	for _, proxy := range s.TrustedProxies {
+		v.check(validAddrOrPrefix(proxy), "server.trusted_proxies", "SERVER_TRUSTED_PROXIES",
+			"%q is not an IP address or CIDR range", proxy)
+	}
+
+	if t := c.TLS; t.Enabled {
+		v.check(t.CertFile != "", "tls.cert_file", "TLS_CERT_FILE", "is required when TLS is enabled")
+		v.check(t.KeyFile != "", "tls.key_file", "TLS_KEY_FILE", "is required when TLS is enabled")
+		v.file(t.CertFile, "tls.cert_file", "TLS_CERT_FILE")
+		v.file(t.KeyFile, "tls.key_file", "TLS_KEY_FILE")
+		v.oneOf(t.MinVersion, []string{"1.2", "1.3"}, "tls.min_version", "TLS_MIN_VERSION")
+		v.nonNegative(t.ReloadInterval, "tls.reload_interval", "TLS_RELOAD_INTERVAL")
+	} else {
+		v.check(!t.HTTP3, "tls.http3", "TLS_HTTP3", "requires TLS to be enabled")
+	}
+
+	if c.GRPC.Enabled {
+		v.port(c.GRPC.Port, "grpc.port", "GRPC_PORT")
+		v.check(c.GRPC.Port != s.Port, "grpc.port", "GRPC_PORT", "must differ from server.port")
+	}
+	if c.Metrics.Enabled && c.Metrics.Port != "" {
+		v.port(c.Metrics.Port, "metrics.port", "METRICS_PORT")
+		v.check(c.Metrics.Port != s.Port, "metrics.port", "METRICS_PORT", "must differ from server.port")
+	}
+
+	v.check(len(c.CORS.AllowedOrigins) > 0, "cors.allowed_origins", "ALLOWED_ORIGINS", "needs at least one origin")
+	for _, origin := range c.CORS.AllowedOrigins {
+		v.check(validOrigin(origin), "cors.allowed_origins", "ALLOWED_ORIGINS",
+			"%q must be an http or https origin such as https://app.example.com", origin)
+	}
+	v.check(len(c.CORS.AllowedMethods) > 0, "cors.allowed_methods", "CORS_ALLOWED_METHODS", "needs at least one method")
+	v.nonNegative(c.CORS.MaxAge, "cors.max_age", "CORS_MAX_AGE")
+
+	v.check(c.Menu.MaxDepth >= 0, "menu.max_depth", "MENU_MAX_DEPTH", "must not be negative; 0 means unlimited")
+	v.check(c.Menu.MaxChildren >= 0, "menu.max_children", "MENU_MAX_CHILDREN", "must not be negative; 0 means unlimited")
+	v.check(c.Events.BufferSize > 0, "events.buffer_size", "EVENTS_BUFFER_SIZE", "must be positive")
+
+	if w := c.Webhook; w.Enabled {
+		v.positive(w.PollInterval, "webhook.poll_interval", "WEBHOOK_POLL_INTERVAL")
+		v.check(w.BatchSize > 0, "webhook.batch_size", "WEBHOOK_BATCH_SIZE", "must be positive")
+		v.check(w.MaxAttempts > 0, "webhook.max_attempts", "WEBHOOK_MAX_ATTEMPTS", "must be positive")
+		v.positive(w.BaseBackoff, "webhook.base_backoff", "WEBHOOK_BASE_BACKOFF")
+		v.check(w.MaxBackoff >= w.BaseBackoff, "webhook.max_backoff", "WEBHOOK_MAX_BACKOFF",
+			"must not be less than webhook.base_backoff")
+		v.positive(w.Timeout, "webhook.timeout", "WEBHOOK_TIMEOUT")
+		v.nonNegative(w.Retention, "webhook.retention", "WEBHOOK_RETENTION")
+	}
+
+	t := c.Tracing
+	v.oneOf(t.Exporter, []string{TracingNone, TracingOTLP, TracingStdout}, "tracing.exporter", "TRACING_EXPORTER")
+	if t.Exporter == TracingOTLP {
+		v.oneOf(t.OTLPProtocol, []string{"grpc", "http"}, "tracing.otlp_protocol", "TRACING_OTLP_PROTOCOL")
+	}
+	v.check(t.SampleRatio >= 0 && t.SampleRatio <= 1, "tracing.sample_ratio", "TRACING_SAMPLE_RATIO",
+		"must be between 0 and 1, got %g", t.SampleRatio)
+	v.check(t.ServiceName != "", "tracing.service_name", "TRACING_SERVICE_NAME", "is required")
+
+	var level slog.Level
+	v.check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level", "LOG_LEVEL",
+		"must be one of debug, info, warn or error, got %q", c.Log.Level)
+	v.oneOf(c.Log.Format, []string{LogFormatJSON, LogFormatText}, "log.format", "LOG_FORMAT")
+	v.nonNegative(c.Log.SlowQueryThreshold, "log.slow_query_threshold", "LOG_SLOW_QUERY_THRESHOLD")
+
+	if r := c.RateLimit; r.Enabled {
+		v.check(r.Reads > 0, "rate_limit.reads", "RATE_LIMIT_READS", "must be positive")
+		v.check(r.Writes > 0, "rate_limit.writes", "RATE_LIMIT_WRITES", "must be positive")
+		v.positive(r.Window, "rate_limit.window", "RATE_LIMIT_WINDOW")
+	}
+
+	if c.Idempotency.Enabled {
+		v.positive(c.Idempotency.TTL, "idempotency.ttl", "IDEMPOTENCY_TTL")
+	}
+
+	return v.problems
+}
+
+func validAddrOrPrefix(s string) bool {
+	if _, err := netip.ParsePrefix(s); err == nil {
+		return true
+	}
+	return net.ParseIP(s) != nil
+}
+
+// validOrigin accepts a scheme and host without path, as sent by browsers
+func validOrigin(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		(u.Path == "" || u.Path == "/") && u.RawQuery == ""
+}
@@ -1,36 +1,109 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
+
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/logging"
 
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type Database struct {
 	DB *gorm.DB
 }
 
-func NewDatabase(dsn string, isDebug bool) (*Database, error) {
-	config := &gorm.Config{}
+// maxConnectBackoff caps the wait between connection attempts
+const maxConnectBackoff = 30 * time.Second
 
-	// Enable SQL logging in development
-	if isDebug {
-		config.Logger = logger.Default.LogMode(logger.Info)
+// NewDatabase connects to the configured database, retrying with exponential
+// backoff so the API can start before the database is ready. Statements are
+// logged to the default slog logger, queries slower than slowQuery as
+// warnings.
+func NewDatabase(cfg config.DatabaseConfig, slowQuery time.Duration) (*Database, error) {
+	if err := registerMySQLTLS(cfg); err != nil {
+		return nil, err
 	}
 
-	db, err := gorm.Open(mysql.Open(dsn), config)
+	dialector, err := newDialector(cfg.Driver, cfg.DSN())
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, err
+	}
+
+	gormConfig := &gorm.Config{
+		// Translate driver errors such as duplicate keys into gorm errors
+		TranslateError: true,
+		Logger:         logging.NewGormLogger(slog.Default(), slowQuery),
 	}
 
-	log.Println("Successfully connected to database")
+	attempts := max(cfg.ConnectAttempts, 1)
+	backoff := cfg.ConnectBackoff
+
+	var db *gorm.DB
+	for attempt := 1; ; attempt++ {
+		db, err = gorm.Open(dialector, gormConfig)
+		if err == nil {
+			break
+		}
+		closeFailed(db)
+
+		if attempt >= attempts {
+			return nil, fmt.Errorf("failed to connect to database after %d attempt(s): %w", attempt, err)
+		}
+		slog.Warn("Database connection attempt failed",
+			"attempt", attempt, "attempts", attempts, "error", err, "retry_in", backoff.String())
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if cfg.Driver == config.DriverSQLite {
+		// SQLite allows a single writer; serialize access instead of
+		// failing with "database is locked"
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	slog.Info("Successfully connected to database", "driver", cfg.Driver)
 
 	return &Database{DB: db}, nil
 }
 
+// closeFailed releases the pool of a connection that failed its first ping
+func closeFailed(db *gorm.DB) {
+	if db == nil {
+		return
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
+// newDialector returns the GORM dialector for a DB_DRIVER value
+func newDialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case config.DriverMySQL:
+		return mysql.Open(dsn), nil
+	case config.DriverPostgres:
+		return postgres.Open(dsn), nil
+	case config.DriverSQLite:
+		return newSQLiteDialector(dsn), nil
+	}
+	return nil, fmt.Errorf("unsupported database driver %q", driver)
+}
+
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
 	if err != nil {
@@ -39,7 +112,15 @@ func (d *Database) Close() error {
 	return sqlDB.Close()
 }
 
+// Ping checks that the database is reachable
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
 func (d *Database) GetDB() *gorm.DB {
 	return d.DB
 }
-
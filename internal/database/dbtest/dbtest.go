@@ -0,0 +1,61 @@
+// Package dbtest provides SQLite databases for tests
+package dbtest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"stk-technical-test-api/database/migrations"
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/database"
+	"stk-technical-test-api/internal/migration"
+
+	"gorm.io/gorm"
+)
+
+// Open creates a SQLite database in a temporary directory, applies every
+// migration and closes it when the test ends
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+	return OpenFile(t, filepath.Join(t.TempDir(), "test.db"))
+}
+
+// OpenFile is Open for a database at path, for tests that hand the file to
+// code opening its own connection
+func OpenFile(t testing.TB, path string) *gorm.DB {
+	t.Helper()
+
+	db := openAt(t, path)
+	migrator, err := migration.New(db, migrations.FS, config.DriverSQLite)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+// OpenEmpty creates a SQLite database in a temporary directory without
+// applying any migration and closes it when the test ends
+func OpenEmpty(t testing.TB) *gorm.DB {
+	t.Helper()
+	return openAt(t, filepath.Join(t.TempDir(), "test.db"))
+}
+
+func openAt(t testing.TB, path string) *gorm.DB {
+	t.Helper()
+
+	db, err := database.NewDatabase(config.DatabaseConfig{
+		Driver:          config.DriverSQLite,
+		Path:            path,
+		MaxIdleConns:    1,
+		ConnectAttempts: 1,
+	}, 0)
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db.GetDB()
+}
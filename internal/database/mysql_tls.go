@@ -0,0 +1,55 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"stk-technical-test-api/internal/config"
+
+	gomysql "github.com/go-sql-driver/mysql"
+)
+
+// registerMySQLTLS registers the TLS configuration referenced by the DSN when
+// a CA, client certificate or server name is configured. The built-in modes
+// need no registration.
+func registerMySQLTLS(cfg config.DatabaseConfig) error {
+	if cfg.Driver != config.DriverMySQL || !cfg.CustomTLS() {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.TLSServerName,
+		InsecureSkipVerify: cfg.TLSMode == config.DBTLSSkipVerify,
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = cfg.Host
+	}
+
+	if cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read database CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in database CA file %s", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load database client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if err := gomysql.RegisterTLSConfig(config.MySQLTLSConfigName, tlsConfig); err != nil {
+		return fmt.Errorf("failed to register database TLS config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// SQLite extended result codes for constraint violations
+const (
+	sqliteConstraintForeignKey = 787
+	sqliteConstraintPrimaryKey = 1555
+	sqliteConstraintUnique     = 2067
+)
+
+// sqliteDialector adds error translation to the pure Go SQLite driver so
+// that TranslateError behaves as it does for MySQL and PostgreSQL
+type sqliteDialector struct {
+	sqlite.Dialector
+}
+
+func newSQLiteDialector(dsn string) gorm.Dialector {
+	return sqliteDialector{Dialector: sqlite.Dialector{DSN: dsn}}
+}
+
+// Translate implements gorm.ErrorTranslator
+func (sqliteDialector) Translate(err error) error {
+	var sqliteErr interface{ Code() int }
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch sqliteErr.Code() {
+	case sqliteConstraintUnique, sqliteConstraintPrimaryKey:
+		return gorm.ErrDuplicatedKey
+	case sqliteConstraintForeignKey:
+		return gorm.ErrForeignKeyViolated
+	}
+	return err
+}
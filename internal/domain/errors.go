@@ -0,0 +1,56 @@
+package domain
+
+import "errors"
+
+// Error kinds. Use errors.Is to check the kind of an error returned by a
+// repository or service.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrHasChildren = errors.New("has children")
+	ErrCycle       = errors.New("cycle")
+	ErrValidation  = errors.New("validation failed")
+)
+
+// Error represents a domain error of a given kind with a client facing message
+type Error struct {
+	Kind       error
+	Message    string
+	Err        error
+	Violations []FieldViolation
+}
+
+// FieldViolation describes why a single request field is invalid
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NewError creates a domain error of the given kind
+func NewError(kind error, message string) error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// WrapError creates a domain error of the given kind caused by err
+func WrapError(kind error, message string, err error) error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// NewValidationError creates a validation error listing every invalid field
+func NewValidationError(violations []FieldViolation) error {
+	return &Error{Kind: ErrValidation, Message: "request validation failed", Violations: violations}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the kind of this error
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
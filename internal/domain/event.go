@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// MenuEventType represents the kind of change applied to a menu
+type MenuEventType string
+
+const (
+	MenuEventCreated   MenuEventType = "menu.created"
+	MenuEventUpdated   MenuEventType = "menu.updated"
+	MenuEventMoved     MenuEventType = "menu.moved"
+	MenuEventDeleted   MenuEventType = "menu.deleted"
+	MenuEventReordered MenuEventType = "menu.reordered"
+)
+
+// MenuEvent represents a change notification for a menu
+type MenuEvent struct {
+	Version     int64         `json:"version"`
+	Type        MenuEventType `json:"type"`
+	MenuID      int64         `json:"menu_id"`
+	AffectedIDs []int64       `json:"affected_ids"`
+	OccurredAt  time.Time     `json:"occurred_at"`
+}
+
+// MenuEventPublisher defines the interface for broadcasting menu events
+type MenuEventPublisher interface {
+	// Publish assigns the next version to the event and delivers it to subscribers
+	Publish(event MenuEvent) MenuEvent
+}
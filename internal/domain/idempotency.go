@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord is the stored response to a request sent with an
+// Idempotency-Key, replayed to retries of the same request
+type IdempotencyRecord struct {
+	ID int64 `gorm:"primaryKey;autoIncrement"`
+	// Scope is the principal or client that sent the request; keys are
+	// unique per scope
+	Scope       string `gorm:"size:255;not null"`
+	Key         string `gorm:"column:idempotency_key;size:255;not null"`
+	RequestHash string `gorm:"size:64;not null"`
+	// StatusCode is zero while the first request is still running
+	StatusCode   int
+	ContentType  string `gorm:"size:255"`
+	ResponseBody string `gorm:"type:text"`
+	CreatedAt    time.Time
+	ExpiresAt    time.Time `gorm:"index"`
+}
+
+// TableName specifies the table name for IdempotencyRecord
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_keys"
+}
+
+// Completed reports whether the response of the first request is stored
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// IdempotencyRepository defines the interface for idempotency key storage
+type IdempotencyRepository interface {
+	// Reserve stores rec as running. When the scope already holds the key
+	// the existing record is returned and rec is not stored, unless that
+	// record has expired or is still running but was created before
+	// abandonedBefore, in which case it is replaced.
+	Reserve(ctx context.Context, rec *IdempotencyRecord, abandonedBefore time.Time) (*IdempotencyRecord, error)
+	// Complete stores the response of a reserved record
+	Complete(ctx context.Context, rec *IdempotencyRecord) error
+	// Release deletes a reserved record so that the request can be retried
+	Release(ctx context.Context, id int64) error
+	// DeleteExpired removes the records that expired before now
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
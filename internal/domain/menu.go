@@ -1,25 +1,28 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Menu represents the menu entity
 type Menu struct {
-	ID          int64      `json:"id" gorm:"primaryKey;autoIncrement"`
-	UUID        string     `json:"uuid" gorm:"size:36;uniqueIndex;not null"`
-	ParentID    *int64     `json:"parent_id" gorm:"index"`
-	Name        string     `json:"name" gorm:"size:255;not null"`
-	Code        string     `json:"code" gorm:"size:100;uniqueIndex"`
-	Description *string    `json:"description" gorm:"type:text"`
-	Route       *string    `json:"route" gorm:"size:255"`
-	Icon        *string    `json:"icon" gorm:"size:100"`
-	OrderIndex  int        `json:"order_index" gorm:"default:0;index"`
-	Level       int        `json:"level" gorm:"default:0"`
-	IsActive    bool       `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	CreatedBy   *int64     `json:"created_by"`
-	UpdatedBy   *int64     `json:"updated_by"`
-	Children    []Menu     `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UUID        string    `json:"uuid" gorm:"size:36;uniqueIndex;not null"`
+	ParentID    *int64    `json:"parent_id" gorm:"index"`
+	Name        string    `json:"name" gorm:"size:255;not null"`
+	Code        string    `json:"code" gorm:"size:100;uniqueIndex"`
+	Description *string   `json:"description" gorm:"type:text"`
+	Route       *string   `json:"route" gorm:"size:255"`
+	Icon        *string   `json:"icon" gorm:"size:100"`
+	OrderIndex  int       `json:"order_index" gorm:"default:0;index"`
+	Level       int       `json:"level" gorm:"default:0"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CreatedBy   *int64    `json:"created_by"`
+	UpdatedBy   *int64    `json:"updated_by"`
+	Children    []Menu    `json:"children,omitempty" gorm:"foreignKey:ParentID"`
 }
 
 // MenuDetail represents menu with parent information
@@ -45,55 +48,98 @@ func (Menu) TableName() string {
 // CreateMenuRequest represents the request payload for creating a menu
 type CreateMenuRequest struct {
 	ParentID    *int64  `json:"parent_id"`
-	Name        string  `json:"name" binding:"required"`
-	Code        string  `json:"code" binding:"required"`
-	Description *string `json:"description"`
-	Route       *string `json:"route"`
-	Icon        *string `json:"icon"`
-	OrderIndex  int     `json:"order_index"`
+	Name        string  `json:"name" validate:"required,max=255"`
+	Code        string  `json:"code" validate:"required,max=100,menucode"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	Route       *string `json:"route" validate:"omitempty,max=255,startswith=/"`
+	Icon        *string `json:"icon" validate:"omitempty,max=100"`
+	OrderIndex  int     `json:"order_index" validate:"gte=0"`
 	IsActive    bool    `json:"is_active"`
 }
 
 // UpdateMenuRequest represents the request payload for updating a menu
 type UpdateMenuRequest struct {
 	ParentID    *int64  `json:"parent_id"`
-	Name        string  `json:"name" binding:"required"`
-	Code        string  `json:"code" binding:"required"`
-	Description *string `json:"description"`
-	Route       *string `json:"route"`
-	Icon        *string `json:"icon"`
-	OrderIndex  int     `json:"order_index"`
+	Name        string  `json:"name" validate:"required,max=255"`
+	Code        string  `json:"code" validate:"required,max=100,menucode"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	Route       *string `json:"route" validate:"omitempty,max=255,startswith=/"`
+	Icon        *string `json:"icon" validate:"omitempty,max=100"`
+	OrderIndex  int     `json:"order_index" validate:"gte=0"`
 	IsActive    bool    `json:"is_active"`
 }
 
+// CloneMenuRequest represents the request payload for copying a menu and
+// its descendants under a new parent. The code of every copy is rewritten
+// with CodePattern and CodeReplacement, then wrapped in CodePrefix and
+// CodeSuffix.
+type CloneMenuRequest struct {
+	ParentID        *int64 `json:"parent_id"`
+	Name            string `json:"name" validate:"max=255"`
+	CodePrefix      string `json:"code_prefix" validate:"max=100"`
+	CodeSuffix      string `json:"code_suffix" validate:"max=100"`
+	CodePattern     string `json:"code_pattern" validate:"max=255"`
+	CodeReplacement string `json:"code_replacement" validate:"max=100"`
+}
+
+// MenuLimits bounds the shape of the menu tree. A zero value disables the
+// corresponding check.
+type MenuLimits struct {
+	MaxDepth    int
+	MaxChildren int
+}
+
+// MenuCounts is the number of menus by active state
+type MenuCounts struct {
+	Active   int64
+	Inactive int64
+}
+
 // MenuRepository defines the interface for menu data operations
 type MenuRepository interface {
-	Create(menu *Menu) error
-	Update(menu *Menu) error
-	Delete(id int64) error
-	FindByID(id int64) (*Menu, error)
-	FindByUUID(uuid string) (*Menu, error)
-	FindAll() ([]Menu, error)
-	FindByParentID(parentID *int64) ([]Menu, error)
-	FindRootMenus() ([]Menu, error)
-	FindHierarchical() ([]Menu, error)
-	FindHierarchicalByRootID(rootID int64) ([]Menu, error)
-	FindDetailByID(id int64) (*MenuDetail, error)
-	FindChildrenByParentID(parentID int64) ([]Menu, error)
+	Create(ctx context.Context, menu *Menu) error
+	Update(ctx context.Context, menu *Menu) error
+	Delete(ctx context.Context, id int64) error
+	FindByID(ctx context.Context, id int64) (*Menu, error)
+	// FindByIDForUpdate locks the row until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id int64) (*Menu, error)
+	FindByUUID(ctx context.Context, uuid string) (*Menu, error)
+	FindByCodes(ctx context.Context, codes []string) ([]Menu, error)
+	FindByIDs(ctx context.Context, ids []int64) ([]Menu, error)
+	FindAll(ctx context.Context) ([]Menu, error)
+	FindByParentID(ctx context.Context, parentID *int64) ([]Menu, error)
+	FindRootMenus(ctx context.Context) ([]Menu, error)
+	FindHierarchical(ctx context.Context) ([]Menu, error)
+	FindHierarchicalByRootID(ctx context.Context, rootID int64) ([]Menu, error)
+	FindDetailByID(ctx context.Context, id int64) (*MenuDetail, error)
+	FindChildrenByParentID(ctx context.Context, parentID int64) ([]Menu, error)
+	FindChildrenByParentIDs(ctx context.Context, parentIDs []int64) ([]Menu, error)
+	Search(ctx context.Context, query string, limit int) ([]Menu, error)
+	CountByActive(ctx context.Context) (*MenuCounts, error)
+	// EnqueueEvent stores a menu change in the outbox
+	EnqueueEvent(ctx context.Context, event *OutboxEvent) error
+	// WithTx runs fn with a repository bound to a single transaction
+	WithTx(ctx context.Context, fn func(repo MenuRepository) error) error
 }
 
 // MenuService defines the interface for menu business logic
 type MenuService interface {
-	CreateMenu(req *CreateMenuRequest) (*Menu, error)
-	UpdateMenu(id int64, req *UpdateMenuRequest) (*Menu, error)
-	DeleteMenu(id int64) error
-	GetMenuByID(id int64) (*Menu, error)
-	GetMenuByUUID(uuid string) (*Menu, error)
-	GetAllMenus() ([]Menu, error)
-	GetRootMenus() ([]Menu, error)
-	GetMenuHierarchy() ([]Menu, error)
-	GetHierarchyByRootID(rootID int64) ([]Menu, error)
-	GetMenuDetail(id int64) (*MenuDetail, error)
-	GetChildrenByParentID(parentID int64) ([]Menu, error)
+	CreateMenu(ctx context.Context, req *CreateMenuRequest) (*Menu, error)
+	UpdateMenu(ctx context.Context, id int64, req *UpdateMenuRequest) (*Menu, error)
+	CloneMenu(ctx context.Context, id int64, req *CloneMenuRequest) (*Menu, error)
+	// InstantiateTemplate creates the menus of tmpl under req.ParentID
+	InstantiateTemplate(ctx context.Context, tmpl *MenuTemplate, req *InstantiateMenuTemplateRequest) (*Menu, error)
+	DeleteMenu(ctx context.Context, id int64) error
+	GetMenuByID(ctx context.Context, id int64) (*Menu, error)
+	GetMenuByUUID(ctx context.Context, uuid string) (*Menu, error)
+	GetAllMenus(ctx context.Context) ([]Menu, error)
+	GetRootMenus(ctx context.Context) ([]Menu, error)
+	GetMenuHierarchy(ctx context.Context) ([]Menu, error)
+	GetHierarchyByRootID(ctx context.Context, rootID int64) ([]Menu, error)
+	GetMenuDetail(ctx context.Context, id int64) (*MenuDetail, error)
+	GetChildrenByParentID(ctx context.Context, parentID int64) ([]Menu, error)
+	GetChildrenByParentIDs(ctx context.Context, parentIDs []int64) (map[int64][]Menu, error)
+	// GetMenusByIDs returns the menus found among ids, keyed by ID
+	GetMenusByIDs(ctx context.Context, ids []int64) (map[int64]Menu, error)
+	SearchMenus(ctx context.Context, query string, limit int) ([]Menu, error)
 }
-
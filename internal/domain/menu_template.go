@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MenuTemplate represents a named blueprint of a menu tree. The name, code
+// and route of its menus may contain {{variable}} placeholders that are
+// filled in when the template is instantiated.
+type MenuTemplate struct {
+	ID          int64            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string           `json:"name" gorm:"size:100;uniqueIndex;not null"`
+	Description *string          `json:"description" gorm:"type:text"`
+	Tree        MenuTemplateNode `json:"tree" gorm:"type:text;serializer:json;not null"`
+	Variables   []string         `json:"variables" gorm:"-"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// TableName specifies the table name for MenuTemplate
+func (MenuTemplate) TableName() string {
+	return "menu_templates"
+}
+
+// MenuTemplateNode represents one menu of a template tree
+type MenuTemplateNode struct {
+	Name        string             `json:"name" validate:"required,max=255"`
+	Code        string             `json:"code" validate:"required,max=255"`
+	Description *string            `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Route       *string            `json:"route,omitempty" validate:"omitempty,max=255"`
+	Icon        *string            `json:"icon,omitempty" validate:"omitempty,max=100"`
+	OrderIndex  int                `json:"order_index" validate:"gte=0"`
+	IsActive    bool               `json:"is_active"`
+	Children    []MenuTemplateNode `json:"children,omitempty" validate:"dive"`
+}
+
+// CreateMenuTemplateRequest represents the request payload for creating a menu template
+type CreateMenuTemplateRequest struct {
+	Name        string           `json:"name" validate:"required,max=100,menucode"`
+	Description *string          `json:"description" validate:"omitempty,max=1000"`
+	Tree        MenuTemplateNode `json:"tree"`
+}
+
+// UpdateMenuTemplateRequest represents the request payload for updating a menu template
+type UpdateMenuTemplateRequest struct {
+	Name        string           `json:"name" validate:"required,max=100,menucode"`
+	Description *string          `json:"description" validate:"omitempty,max=1000"`
+	Tree        MenuTemplateNode `json:"tree"`
+}
+
+// InstantiateMenuTemplateRequest represents the request payload for creating
+// the menus of a template under a parent
+type InstantiateMenuTemplateRequest struct {
+	ParentID  *int64            `json:"parent_id"`
+	Variables map[string]string `json:"variables"`
+}
+
+// MenuTemplateRepository defines the interface for menu template data operations
+type MenuTemplateRepository interface {
+	Create(ctx context.Context, tmpl *MenuTemplate) error
+	Update(ctx context.Context, tmpl *MenuTemplate) error
+	Delete(ctx context.Context, id int64) error
+	FindByName(ctx context.Context, name string) (*MenuTemplate, error)
+	FindAll(ctx context.Context) ([]MenuTemplate, error)
+}
+
+// MenuTemplateService defines the interface for menu template business logic
+type MenuTemplateService interface {
+	CreateTemplate(ctx context.Context, req *CreateMenuTemplateRequest) (*MenuTemplate, error)
+	UpdateTemplate(ctx context.Context, name string, req *UpdateMenuTemplateRequest) (*MenuTemplate, error)
+	DeleteTemplate(ctx context.Context, name string) error
+	GetTemplate(ctx context.Context, name string) (*MenuTemplate, error)
+	GetAllTemplates(ctx context.Context) ([]MenuTemplate, error)
+	InstantiateTemplate(ctx context.Context, name string, req *InstantiateMenuTemplateRequest) (*Menu, error)
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// OutboxEvent represents a menu change persisted in the same transaction
+// as the mutation, waiting to be fanned out to webhook subscriptions
+type OutboxEvent struct {
+	ID           int64         `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventType    MenuEventType `json:"type" gorm:"size:50;not null"`
+	MenuID       int64         `json:"menu_id" gorm:"not null"`
+	AffectedIDs  []int64       `json:"affected_ids" gorm:"type:text;serializer:json"`
+	OccurredAt   time.Time     `json:"occurred_at"`
+	DispatchedAt *time.Time    `json:"-" gorm:"index"`
+}
+
+// TableName specifies the table name for OutboxEvent
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// WebhookSubscription represents an external endpoint notified of menu changes
+type WebhookSubscription struct {
+	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UUID       string    `json:"uuid" gorm:"size:36;uniqueIndex;not null"`
+	URL        string    `json:"url" gorm:"size:2048;not null"`
+	Secret     string    `json:"-" gorm:"size:255;not null"`
+	EventTypes []string  `json:"event_types" gorm:"type:text;serializer:json"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for WebhookSubscription
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Matches reports whether the subscription wants the given event type.
+// An empty filter or "*" matches every event.
+func (s *WebhookSubscription) Matches(eventType MenuEventType) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == "*" || t == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery represents the delivery of one outbox event to one subscription
+type WebhookDelivery struct {
+	ID             int64               `json:"id" gorm:"primaryKey;autoIncrement"`
+	SubscriptionID int64               `json:"subscription_id" gorm:"index;not null"`
+	OutboxEventID  int64               `json:"event_id" gorm:"index;not null"`
+	EventType      MenuEventType       `json:"event_type" gorm:"size:50;not null"`
+	Status         string              `json:"status" gorm:"size:20;index;not null"`
+	Attempts       int                 `json:"attempts" gorm:"default:0"`
+	ResponseStatus *int                `json:"response_status"`
+	LastError      *string             `json:"last_error" gorm:"type:text"`
+	NextAttemptAt  time.Time           `json:"next_attempt_at" gorm:"index"`
+	DeliveredAt    *time.Time          `json:"delivered_at"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	Subscription   WebhookSubscription `json:"-" gorm:"foreignKey:SubscriptionID"`
+	OutboxEvent    OutboxEvent         `json:"-" gorm:"foreignKey:OutboxEventID"`
+}
+
+// TableName specifies the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// CreateWebhookRequest represents the request payload for creating a webhook subscription
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	Secret     string   `json:"secret" binding:"required,min=16"`
+	EventTypes []string `json:"event_types"`
+	IsActive   bool     `json:"is_active"`
+}
+
+// UpdateWebhookRequest represents the request payload for updating a webhook subscription.
+// An empty secret keeps the current one.
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16"`
+	EventTypes []string `json:"event_types"`
+	IsActive   bool     `json:"is_active"`
+}
+
+// WebhookRepository defines the interface for webhook data operations
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *WebhookSubscription) error
+	UpdateSubscription(ctx context.Context, sub *WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int64) error
+	FindSubscriptionByID(ctx context.Context, id int64) (*WebhookSubscription, error)
+	FindAllSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	FindDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64, limit int) ([]WebhookDelivery, error)
+	// DispatchPendingEvents fans undispatched outbox events out into
+	// deliveries for matching active subscriptions and returns how many
+	// events were dispatched
+	DispatchPendingEvents(ctx context.Context, limit int, now time.Time) (int, error)
+	// ClaimDueDeliveries returns pending deliveries due at now and leases
+	// them until now+lease so concurrent workers skip them
+	ClaimDueDeliveries(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	// PurgeDispatchedEvents deletes the outbox events dispatched before
+	// before together with their finished deliveries and returns how many
+	// events were deleted. Events with pending deliveries are kept.
+	PurgeDispatchedEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhookService defines the interface for webhook business logic
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, req *CreateWebhookRequest) (*WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id int64, req *UpdateWebhookRequest) (*WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	GetSubscription(ctx context.Context, id int64) (*WebhookSubscription, error)
+	GetAllSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]WebhookDelivery, error)
+}
+
+// IsPrivateAddress reports whether ip is a loopback, private, link-local or
+// unspecified address. Webhooks are not sent to such addresses unless
+// private URLs are allowed.
+func IsPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
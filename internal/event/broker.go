@@ -0,0 +1,145 @@
+package event
+
+import (
+	"sync"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// subscriberBuffer is the channel capacity of each subscriber. Slow
+// subscribers that fall further behind are dropped and must resume
+// using Last-Event-ID.
+const subscriberBuffer = 64
+
+// Subscription represents a live subscription to the broker
+type Subscription struct {
+	Events <-chan domain.MenuEvent
+	// Backlog holds buffered events newer than the requested version
+	Backlog []domain.MenuEvent
+	// Gap is true when the requested version is older than the buffer, or
+	// newer than the latest version as after a server restart, meaning some
+	// events were lost and the client should refetch
+	Gap bool
+
+	ch chan domain.MenuEvent
+}
+
+// Broker fans out menu events to subscribers and keeps a bounded
+// in-memory history for resuming streams
+type Broker struct {
+	mu          sync.Mutex
+	version     int64
+	buffer      []domain.MenuEvent
+	size        int
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// NewBroker creates a new event broker that retains up to bufferSize events
+func NewBroker(bufferSize int) *Broker {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+
+	return &Broker{
+		buffer:      make([]domain.MenuEvent, 0, bufferSize),
+		size:        bufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish assigns the next version to the event, stores it in the
+// history buffer and delivers it to every subscriber
+func (b *Broker) Publish(e domain.MenuEvent) domain.MenuEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.version++
+	e.Version = b.version
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+
+	if len(b.buffer) == b.size {
+		copy(b.buffer, b.buffer[1:])
+		b.buffer = b.buffer[:b.size-1]
+	}
+	b.buffer = append(b.buffer, e)
+
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- e:
+		default:
+			// Subscriber is too slow, drop it so it reconnects and resumes
+			delete(b.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+
+	return e
+}
+
+// Subscribe registers a new subscriber. When lastVersion is greater than
+// zero, buffered events newer than it are returned in the backlog. A
+// lastVersion the broker has not reached yet was issued by an earlier
+// process, so the subscriber cannot tell what it missed and gets a gap.
+func (b *Broker) Subscribe(lastVersion int64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan domain.MenuEvent, subscriberBuffer)
+	sub := &Subscription{Events: ch, ch: ch}
+
+	if b.closed {
+		close(ch)
+		return sub
+	}
+
+	if lastVersion > b.version {
+		sub.Gap = true
+	} else if lastVersion > 0 && lastVersion < b.version {
+		if len(b.buffer) > 0 && b.buffer[0].Version > lastVersion+1 {
+			sub.Gap = true
+		}
+		for _, e := range b.buffer {
+			if e.Version > lastVersion {
+				sub.Backlog = append(sub.Backlog, e)
+			}
+		}
+	}
+
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe removes the subscriber and closes its channel
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Close ends every subscription. Later subscriptions are closed immediately,
+// while events are still recorded in the history buffer.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Version returns the latest published version
+func (b *Broker) Version() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.version
+}
@@ -0,0 +1,124 @@
+package event
+
+import (
+	"slices"
+	"testing"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// publishN publishes n events and returns the broker
+func publishN(bufferSize, n int) *Broker {
+	b := NewBroker(bufferSize)
+	for i := 0; i < n; i++ {
+		b.Publish(domain.MenuEvent{Type: domain.MenuEventCreated, MenuID: int64(i + 1)})
+	}
+	return b
+}
+
+func versions(events []domain.MenuEvent) []int64 {
+	out := make([]int64, len(events))
+	for i, e := range events {
+		out[i] = e.Version
+	}
+	return out
+}
+
+func TestSubscribeResume(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastVersion int64
+		wantGap     bool
+		wantBacklog []int64
+	}{
+		{name: "new subscriber", lastVersion: 0, wantBacklog: []int64{}},
+		{name: "up to date", lastVersion: 5, wantBacklog: []int64{}},
+		{name: "within buffer", lastVersion: 3, wantBacklog: []int64{4, 5}},
+		{name: "just before buffer", lastVersion: 2, wantBacklog: []int64{3, 4, 5}},
+		{name: "older than buffer", lastVersion: 1, wantGap: true, wantBacklog: []int64{3, 4, 5}},
+		// A version from before a restart, when the counter started over
+		{name: "ahead of broker", lastVersion: 42, wantGap: true, wantBacklog: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := publishN(3, 5)
+			defer b.Close()
+
+			sub := b.Subscribe(tt.lastVersion)
+			if sub.Gap != tt.wantGap {
+				t.Errorf("gap = %v, want %v", sub.Gap, tt.wantGap)
+			}
+			if got := versions(sub.Backlog); !slices.Equal(got, tt.wantBacklog) {
+				t.Errorf("backlog = %v, want %v", got, tt.wantBacklog)
+			}
+		})
+	}
+}
+
+func TestRestartedBrokerResetsReconnectingClient(t *testing.T) {
+	before := publishN(10, 7)
+	lastSeen := before.Version()
+	before.Close()
+
+	// The new process has published fewer events than the client has seen
+	after := publishN(10, 2)
+	defer after.Close()
+
+	sub := after.Subscribe(lastSeen)
+	if !sub.Gap || len(sub.Backlog) != 0 {
+		t.Errorf("gap, backlog = %v, %v, want a gap without backlog", sub.Gap, versions(sub.Backlog))
+	}
+
+	// Events published afterwards still reach the subscriber
+	after.Publish(domain.MenuEvent{Type: domain.MenuEventDeleted, MenuID: 1})
+	if e := <-sub.Events; e.Version != 3 {
+		t.Errorf("next event version = %d, want 3", e.Version)
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	b := NewBroker(10)
+	defer b.Close()
+
+	slow := b.Subscribe(0)
+	fast := b.Subscribe(0)
+
+	received := 0
+	for i := 0; i < subscriberBuffer+1; i++ {
+		b.Publish(domain.MenuEvent{Type: domain.MenuEventUpdated, MenuID: 1})
+		<-fast.Events
+		received++
+	}
+
+	// The slow subscriber gets the buffered events, then a closed channel
+	count := 0
+	for range slow.Events {
+		count++
+	}
+	if count != subscriberBuffer {
+		t.Errorf("slow subscriber got %d events, want %d", count, subscriberBuffer)
+	}
+	if received != subscriberBuffer+1 {
+		t.Errorf("fast subscriber got %d events, want %d", received, subscriberBuffer+1)
+	}
+
+	// The history buffer keeps only the newest events
+	sub := b.Subscribe(1)
+	if !sub.Gap || len(sub.Backlog) != 10 || sub.Backlog[0].Version != int64(subscriberBuffer+1-9) {
+		t.Errorf("gap, backlog = %v, %v", sub.Gap, versions(sub.Backlog))
+	}
+}
+
+func TestClosedBrokerClosesSubscriptions(t *testing.T) {
+	b := NewBroker(10)
+	sub := b.Subscribe(0)
+	b.Close()
+
+	if _, ok := <-sub.Events; ok {
+		t.Error("subscription open after Close")
+	}
+	if _, ok := <-b.Subscribe(0).Events; ok {
+		t.Error("subscription after Close is open")
+	}
+}
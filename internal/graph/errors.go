@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"errors"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// resolverError exposes the field violations of a validation error as
+// GraphQL error extensions
+type resolverError struct {
+	err        error
+	violations []domain.FieldViolation
+}
+
+func (e *resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+// Extensions is picked up by graphql-go and rendered in the error response
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":       "validation_failed",
+		"violations": e.violations,
+	}
+}
+
+// toResolverError attaches violations to err when it carries any
+func toResolverError(err error) error {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) && len(domainErr.Violations) > 0 {
+		return &resolverError{err: err, violations: domainErr.Violations}
+	}
+	return err
+}
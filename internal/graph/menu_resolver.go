@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// unlimitedDepth disables the children depth limit
+const unlimitedDepth = -1
+
+// childBatch loads the children of every menu on one tree level with a
+// single query the first time any of them resolves its children field,
+// so a nested query costs one query per level instead of one per menu
+type childBatch struct {
+	service  domain.MenuService
+	ids      []int64
+	once     sync.Once
+	children map[int64][]domain.Menu
+	next     *childBatch
+	err      error
+
+	// parents loads the parents of the same menus
+	parents *parentBatch
+}
+
+func newChildBatch(service domain.MenuService, menus []domain.Menu) *childBatch {
+	batch := &childBatch{service: service, parents: newParentBatch(service, menus)}
+	for _, menu := range menus {
+		batch.ids = append(batch.ids, menu.ID)
+	}
+	return batch
+}
+
+func (b *childBatch) load(ctx context.Context) error {
+	b.once.Do(func() {
+		b.children, b.err = b.service.GetChildrenByParentIDs(ctx, b.ids)
+		if b.err != nil {
+			return
+		}
+
+		var level []domain.Menu
+		for _, menus := range b.children {
+			level = append(level, menus...)
+		}
+		b.next = newChildBatch(b.service, level)
+	})
+	return b.err
+}
+
+// parentBatch loads the parents of every menu on one tree level with a
+// single query, the same way childBatch loads their children. The parents
+// share batches of their own, so following parent links up the tree also
+// costs one query per level.
+type parentBatch struct {
+	service domain.MenuService
+	ids     []int64
+	once    sync.Once
+	parents map[int64]*menuResolver
+	err     error
+}
+
+func newParentBatch(service domain.MenuService, menus []domain.Menu) *parentBatch {
+	batch := &parentBatch{service: service}
+	for _, menu := range menus {
+		if menu.ParentID != nil && !slices.Contains(batch.ids, *menu.ParentID) {
+			batch.ids = append(batch.ids, *menu.ParentID)
+		}
+	}
+	return batch
+}
+
+func (b *parentBatch) load(ctx context.Context) error {
+	b.once.Do(func() {
+		found, err := b.service.GetMenusByIDs(ctx, b.ids)
+		if err != nil {
+			b.err = err
+			return
+		}
+
+		parents := make([]domain.Menu, 0, len(found))
+		for _, id := range b.ids {
+			if parent, ok := found[id]; ok {
+				parents = append(parents, parent)
+			}
+		}
+
+		b.parents = make(map[int64]*menuResolver, len(parents))
+		for _, resolver := range newMenuResolvers(b.service, parents, 0, unlimitedDepth) {
+			b.parents[resolver.menu.ID] = resolver
+		}
+	})
+	return b.err
+}
+
+type menuResolver struct {
+	menu     domain.Menu
+	batch    *childBatch
+	depth    int
+	maxDepth int
+}
+
+// newMenuResolvers wraps menus that sit on the same tree level so they
+// share their children and parent batches
+func newMenuResolvers(service domain.MenuService, menus []domain.Menu, depth, maxDepth int) []*menuResolver {
+	return wrapMenus(newChildBatch(service, menus), menus, depth, maxDepth)
+}
+
+func wrapMenus(batch *childBatch, menus []domain.Menu, depth, maxDepth int) []*menuResolver {
+	resolvers := make([]*menuResolver, 0, len(menus))
+	for _, menu := range menus {
+		resolvers = append(resolvers, &menuResolver{
+			menu:     menu,
+			batch:    batch,
+			depth:    depth,
+			maxDepth: maxDepth,
+		})
+	}
+	return resolvers
+}
+
+func (m *menuResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(m.menu.ID, 10))
+}
+
+func (m *menuResolver) UUID() string {
+	return m.menu.UUID
+}
+
+func (m *menuResolver) ParentID() *graphql.ID {
+	if m.menu.ParentID == nil {
+		return nil
+	}
+	id := graphql.ID(strconv.FormatInt(*m.menu.ParentID, 10))
+	return &id
+}
+
+func (m *menuResolver) Name() string {
+	return m.menu.Name
+}
+
+func (m *menuResolver) Code() string {
+	return m.menu.Code
+}
+
+func (m *menuResolver) Description() *string {
+	return m.menu.Description
+}
+
+func (m *menuResolver) Route() *string {
+	return m.menu.Route
+}
+
+func (m *menuResolver) Icon() *string {
+	return m.menu.Icon
+}
+
+func (m *menuResolver) OrderIndex() int32 {
+	return int32(m.menu.OrderIndex)
+}
+
+func (m *menuResolver) Level() int32 {
+	return int32(m.menu.Level)
+}
+
+func (m *menuResolver) IsActive() bool {
+	return m.menu.IsActive
+}
+
+func (m *menuResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: m.menu.CreatedAt}
+}
+
+func (m *menuResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: m.menu.UpdatedAt}
+}
+
+func (m *menuResolver) Parent(ctx context.Context) (*menuResolver, error) {
+	if m.menu.ParentID == nil {
+		return nil, nil
+	}
+
+	if err := m.batch.parents.load(ctx); err != nil {
+		return nil, err
+	}
+	parent, ok := m.batch.parents.parents[*m.menu.ParentID]
+	if !ok {
+		return nil, domain.NewError(domain.ErrNotFound, "menu not found")
+	}
+	return parent, nil
+}
+
+func (m *menuResolver) Children(ctx context.Context) ([]*menuResolver, error) {
+	if m.maxDepth != unlimitedDepth && m.depth >= m.maxDepth {
+		return []*menuResolver{}, nil
+	}
+
+	if err := m.batch.load(ctx); err != nil {
+		return nil, err
+	}
+
+	return wrapMenus(m.batch.next, m.batch.children[m.menu.ID], m.depth+1, m.maxDepth), nil
+}
@@ -0,0 +1,194 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/service"
+)
+
+// countingService counts the reads the resolvers make
+type countingService struct {
+	domain.MenuService
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (s *countingService) count(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+}
+
+// take returns and resets the counts
+func (s *countingService) take() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := s.calls
+	s.calls = map[string]int{}
+	return calls
+}
+
+func (s *countingService) GetMenuByID(ctx context.Context, id int64) (*domain.Menu, error) {
+	s.count("GetMenuByID")
+	return s.MenuService.GetMenuByID(ctx, id)
+}
+
+func (s *countingService) GetChildrenByParentID(ctx context.Context, parentID int64) ([]domain.Menu, error) {
+	s.count("GetChildrenByParentID")
+	return s.MenuService.GetChildrenByParentID(ctx, parentID)
+}
+
+func (s *countingService) GetChildrenByParentIDs(ctx context.Context, parentIDs []int64) (map[int64][]domain.Menu, error) {
+	s.count("GetChildrenByParentIDs")
+	return s.MenuService.GetChildrenByParentIDs(ctx, parentIDs)
+}
+
+func (s *countingService) GetMenusByIDs(ctx context.Context, ids []int64) (map[int64]domain.Menu, error) {
+	s.count("GetMenusByIDs")
+	return s.MenuService.GetMenusByIDs(ctx, ids)
+}
+
+// newCountingService returns a service holding two trees:
+//
+//	a ─ a1 ─ a11
+//	  └ a2 ─ a21
+//	b ─ b1
+func newCountingService(t *testing.T) *countingService {
+	t.Helper()
+	svc := &countingService{
+		MenuService: service.NewMenuService(repository.NewMemoryMenuRepository(), nil, domain.MenuLimits{}),
+		calls:       map[string]int{},
+	}
+
+	ids := map[string]int64{}
+	for _, m := range []struct{ code, parent string }{
+		{"a", ""}, {"b", ""}, {"a1", "a"}, {"a2", "a"}, {"b1", "b"}, {"a11", "a1"}, {"a21", "a2"},
+	} {
+		req := &domain.CreateMenuRequest{Name: m.code, Code: m.code, IsActive: true}
+		if m.parent != "" {
+			parentID := ids[m.parent]
+			req.ParentID = &parentID
+		}
+		menu, err := svc.CreateMenu(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[m.code] = menu.ID
+	}
+	return svc
+}
+
+// menuNode is the part of the Menu type the tests select
+type menuNode struct {
+	Code     string
+	Parent   *menuNode
+	Children []menuNode
+}
+
+func execute(t *testing.T, svc domain.MenuService, query string, data any) {
+	t.Helper()
+	schema, err := NewSchema(svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := schema.Exec(context.Background(), query, "", nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("query failed: %v", resp.Errors)
+	}
+	if err := json.Unmarshal(resp.Data, data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// codes lists the codes of menus and their children, depth first
+func codes(menus []menuNode) []string {
+	var out []string
+	for _, m := range menus {
+		out = append(out, m.Code)
+		out = append(out, codes(m.Children)...)
+	}
+	return out
+}
+
+func TestMenuTreeMaxDepth(t *testing.T) {
+	svc := newCountingService(t)
+
+	tests := []struct {
+		maxDepth  string
+		want      []string
+		wantLoads int
+	}{
+		{maxDepth: "0", want: []string{"a", "b"}, wantLoads: 0},
+		{maxDepth: "1", want: []string{"a", "a1", "a2", "b", "b1"}, wantLoads: 1},
+		{maxDepth: "2", want: []string{"a", "a1", "a11", "a2", "a21", "b", "b1"}, wantLoads: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run("maxDepth "+tt.maxDepth, func(t *testing.T) {
+			var data struct{ MenuTree []menuNode }
+			execute(t, svc, `query { menuTree(maxDepth: `+tt.maxDepth+`) { code children { code children { code children { code } } } } }`, &data)
+
+			if got := codes(data.MenuTree); !slices.Equal(got, tt.want) {
+				t.Fatalf("codes = %v, want %v", got, tt.want)
+			}
+
+			// One children query per level, whatever the number of menus on it
+			calls := svc.take()
+			if calls["GetChildrenByParentIDs"] != tt.wantLoads || calls["GetChildrenByParentID"] != 0 {
+				t.Errorf("calls = %v, want %d batched children loads", calls, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestMenuTreeUnlimitedLoadsOneQueryPerLevel(t *testing.T) {
+	svc := newCountingService(t)
+
+	var data struct{ MenuTree []menuNode }
+	execute(t, svc, `query { menuTree { code children { code children { code children { code } } } } }`, &data)
+
+	if got := len(codes(data.MenuTree)); got != 7 {
+		t.Errorf("%d menus in the tree, want 7", got)
+	}
+	// Roots, their children, grandchildren and the empty fourth level
+	if calls := svc.take(); calls["GetChildrenByParentIDs"] != 3 {
+		t.Errorf("calls = %v, want 3 batched children loads", calls)
+	}
+}
+
+func TestParentsLoadOneQueryPerLevel(t *testing.T) {
+	svc := newCountingService(t)
+
+	var data struct{ Menus []menuNode }
+	execute(t, svc, `query { menus { code parent { code parent { code } } } }`, &data)
+
+	parents := map[string]string{}
+	grandparents := map[string]string{}
+	for _, m := range data.Menus {
+		if m.Parent != nil {
+			parents[m.Code] = m.Parent.Code
+			if m.Parent.Parent != nil {
+				grandparents[m.Code] = m.Parent.Parent.Code
+			}
+		}
+	}
+	wantParents := map[string]string{"a1": "a", "a2": "a", "b1": "b", "a11": "a1", "a21": "a2"}
+	wantGrandparents := map[string]string{"a11": "a", "a21": "a"}
+	if !maps.Equal(parents, wantParents) || !maps.Equal(grandparents, wantGrandparents) {
+		t.Errorf("parents = %v, grandparents = %v, want %v and %v", parents, grandparents, wantParents, wantGrandparents)
+	}
+
+	// One query for the parents of all seven menus, one for their parents
+	if calls := svc.take(); calls["GetMenusByIDs"] != 2 || calls["GetMenuByID"] != 0 {
+		t.Errorf("calls = %v, want 2 batched parent loads", calls)
+	}
+}
@@ -0,0 +1,231 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Resolver is the root resolver for queries and mutations
+type Resolver struct {
+	service domain.MenuService
+}
+
+type menuArgs struct {
+	ID   *graphql.ID
+	UUID *string
+}
+
+func (r *Resolver) Menu(ctx context.Context, args menuArgs) (*menuResolver, error) {
+	var (
+		menu *domain.Menu
+		err  error
+	)
+
+	switch {
+	case args.ID != nil:
+		id, parseErr := parseID(*args.ID)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		menu, err = r.service.GetMenuByID(ctx, id)
+	case args.UUID != nil:
+		menu, err = r.service.GetMenuByUUID(ctx, *args.UUID)
+	default:
+		return nil, fmt.Errorf("either id or uuid is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newMenuResolvers(r.service, []domain.Menu{*menu}, 0, unlimitedDepth)[0], nil
+}
+
+type menusArgs struct {
+	ParentID *graphql.ID
+	RootOnly *bool
+}
+
+func (r *Resolver) Menus(ctx context.Context, args menusArgs) ([]*menuResolver, error) {
+	var (
+		menus []domain.Menu
+		err   error
+	)
+
+	switch {
+	case args.ParentID != nil:
+		parentID, parseErr := parseID(*args.ParentID)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		menus, err = r.service.GetChildrenByParentID(ctx, parentID)
+	case args.RootOnly != nil && *args.RootOnly:
+		menus, err = r.service.GetRootMenus(ctx)
+	default:
+		menus, err = r.service.GetAllMenus(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newMenuResolvers(r.service, menus, 0, unlimitedDepth), nil
+}
+
+type menuTreeArgs struct {
+	RootID   *graphql.ID
+	MaxDepth *int32
+}
+
+func (r *Resolver) MenuTree(ctx context.Context, args menuTreeArgs) ([]*menuResolver, error) {
+	maxDepth := unlimitedDepth
+	if args.MaxDepth != nil {
+		if *args.MaxDepth < 0 {
+			return nil, fmt.Errorf("maxDepth must not be negative")
+		}
+		maxDepth = int(*args.MaxDepth)
+	}
+
+	var roots []domain.Menu
+	if args.RootID != nil {
+		rootID, err := parseID(*args.RootID)
+		if err != nil {
+			return nil, err
+		}
+		root, err := r.service.GetMenuByID(ctx, rootID)
+		if err != nil {
+			return nil, err
+		}
+		roots = []domain.Menu{*root}
+	} else {
+		var err error
+		roots, err = r.service.GetRootMenus(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newMenuResolvers(r.service, roots, 0, maxDepth), nil
+}
+
+type searchArgs struct {
+	Query string
+	Limit *int32
+}
+
+func (r *Resolver) Search(ctx context.Context, args searchArgs) ([]*menuResolver, error) {
+	limit := 0
+	if args.Limit != nil {
+		limit = int(*args.Limit)
+	}
+
+	menus, err := r.service.SearchMenus(ctx, args.Query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMenuResolvers(r.service, menus, 0, unlimitedDepth), nil
+}
+
+type menuInput struct {
+	ParentID    *graphql.ID
+	Name        string
+	Code        string
+	Description *string
+	Route       *string
+	Icon        *string
+	OrderIndex  int32
+	IsActive    bool
+}
+
+func (in menuInput) toRequest() (*domain.CreateMenuRequest, error) {
+	parentID, err := parseOptionalID(in.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &domain.CreateMenuRequest{
+		ParentID:    parentID,
+		Name:        in.Name,
+		Code:        in.Code,
+		Description: in.Description,
+		Route:       in.Route,
+		Icon:        in.Icon,
+		OrderIndex:  int(in.OrderIndex),
+		IsActive:    in.IsActive,
+	}
+	return req, nil
+}
+
+func (r *Resolver) CreateMenu(ctx context.Context, args struct{ Input menuInput }) (*menuResolver, error) {
+	req, err := args.Input.toRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	menu, err := r.service.CreateMenu(ctx, req)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+
+	return newMenuResolvers(r.service, []domain.Menu{*menu}, 0, unlimitedDepth)[0], nil
+}
+
+type updateMenuArgs struct {
+	ID    graphql.ID
+	Input menuInput
+}
+
+func (r *Resolver) UpdateMenu(ctx context.Context, args updateMenuArgs) (*menuResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := args.Input.toRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	menu, err := r.service.UpdateMenu(ctx, id, (*domain.UpdateMenuRequest)(req))
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+
+	return newMenuResolvers(r.service, []domain.Menu{*menu}, 0, unlimitedDepth)[0], nil
+}
+
+func (r *Resolver) DeleteMenu(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.service.DeleteMenu(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	parsed, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid menu id: %s", id)
+	}
+	return parsed, nil
+}
+
+func parseOptionalID(id *graphql.ID) (*int64, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	parsed, err := parseID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,22 @@
+package graph
+
+import (
+	_ "embed"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// maxQueryDepth bounds how deeply a single query may nest selections
+const maxQueryDepth = 20
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// NewSchema parses the GraphQL schema and binds it to the menu service
+func NewSchema(service domain.MenuService) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schemaSDL, &Resolver{service: service},
+		graphql.MaxDepth(maxQueryDepth),
+	)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var errTrailingData = errors.New("request body must contain a single JSON value")
+
+// bindJSON decodes the request body into obj and validates its binding
+// tags. Unknown fields are rejected so that misspelled fields do not go
+// unnoticed, and so is anything after the JSON value. On failure the error
+// response is written and false returned.
+func bindJSON(c *gin.Context, obj any) bool {
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(obj)
+	if err == nil {
+		if _, tokErr := decoder.Token(); tokErr != io.EOF {
+			err = errTrailingData
+			if errors.As(tokErr, new(*http.MaxBytesError)) {
+				err = tokErr
+			}
+		}
+	}
+	if err == nil {
+		err = binding.Validator.ValidateStruct(obj)
+	}
+	if err == nil {
+		return true
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		response.Error(c, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge, "Request body too large",
+			fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+		return false
+	}
+	respondBadRequest(c, "Invalid request", err.Error())
+	return false
+}
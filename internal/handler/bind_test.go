@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBindJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type item struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantDetail string
+	}{
+		{name: "valid", body: `{"name":"a"}`, wantStatus: http.StatusOK},
+		{name: "trailing whitespace", body: "{\"name\":\"a\"}\n", wantStatus: http.StatusOK},
+		{name: "second value", body: `{"name":"a"}{"x":1}`, wantStatus: http.StatusBadRequest, wantDetail: "single JSON value"},
+		{name: "trailing garbage", body: `{"name":"a"} x`, wantStatus: http.StatusBadRequest, wantDetail: "single JSON value"},
+		{name: "unknown field", body: `{"name":"a","x":1}`, wantStatus: http.StatusBadRequest, wantDetail: "unknown field"},
+		{name: "missing required field", body: `{}`, wantStatus: http.StatusBadRequest, wantDetail: "required"},
+		{name: "too large", body: `{"name":"` + strings.Repeat("a", 64) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "trailing data over the limit", body: `{"name":"a"}` + strings.Repeat(" ", 64) + "x", wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/items", func(c *gin.Context) {
+				c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 32)
+				var obj item
+				if bindJSON(c, &obj) {
+					c.Status(http.StatusOK)
+				}
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus || !strings.Contains(rec.Body.String(), tt.wantDetail) {
+				t.Errorf("%d %s, want %d containing %q", rec.Code, rec.Body, tt.wantStatus, tt.wantDetail)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIPage renders the interactive documentation for /openapi.json
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>STK Menu API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+type DocsHandler struct {
+	spec []byte
+}
+
+func NewDocsHandler(spec []byte) *DocsHandler {
+	return &DocsHandler{
+		spec: spec,
+	}
+}
+
+// GetOpenAPISpec godoc
+// @Summary OpenAPI specification
+// @Description Get the OpenAPI 3 document of this API
+// @Tags docs
+// @Produce json
+// @Success 200 {object} object
+// @Router /openapi.json [get]
+func (h *DocsHandler) GetOpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// GetDocs godoc
+// @Summary API documentation
+// @Description Interactive API documentation rendered from /openapi.json
+// @Tags docs
+// @Produce html
+// @Success 200 {string} string "HTML page"
+// @Router /docs [get]
+func (h *DocsHandler) GetDocs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
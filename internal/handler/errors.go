@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statusClientClosedRequest is the nginx status for a request the client
+// gave up on before the response was written
+const statusClientClosedRequest = 499
+
+// errorStatus maps a domain error kind to its HTTP status and error code
+func errorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, response.CodeNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict, response.CodeConflict
+	case errors.Is(err, domain.ErrHasChildren):
+		return http.StatusConflict, response.CodeHasChildren
+	case errors.Is(err, domain.ErrCycle):
+		return http.StatusUnprocessableEntity, response.CodeCycle
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusUnprocessableEntity, response.CodeValidationFailed
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, response.CodeTimeout
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest, response.CodeClientClosedRequest
+	}
+	return http.StatusInternalServerError, response.CodeInternalError
+}
+
+// respondError writes a service error using the status and code of its kind
+func respondError(c *gin.Context, message string, err error) {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) && len(domainErr.Violations) > 0 {
+		violations := make([]response.Violation, 0, len(domainErr.Violations))
+		for _, v := range domainErr.Violations {
+			violations = append(violations, response.Violation{Field: v.Field, Message: v.Message})
+		}
+		response.Invalid(c, message, err.Error(), violations)
+		return
+	}
+
+	status, code := errorStatus(err)
+	if status == http.StatusInternalServerError {
+		// Record the cause for the request log
+		_ = c.Error(err)
+	}
+	response.Error(c, status, code, message, err.Error())
+}
+
+// respondBadRequest writes a 400 for malformed path parameters or bodies
+func respondBadRequest(c *gin.Context, message string, detail string) {
+	response.Error(c, http.StatusBadRequest, response.CodeInvalidRequest, message, detail)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantLogged bool
+	}{
+		{name: "not found", err: domain.NewError(domain.ErrNotFound, "menu not found"), wantStatus: http.StatusNotFound, wantCode: "not_found"},
+		{name: "timeout", err: fmt.Errorf("get menu: %w", context.DeadlineExceeded), wantStatus: http.StatusGatewayTimeout, wantCode: "timeout"},
+		{name: "client disconnected", err: fmt.Errorf("get menu: %w", context.Canceled), wantStatus: statusClientClosedRequest, wantCode: "client_closed_request"},
+		{name: "internal", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantCode: "internal_error", wantLogged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/menus/1", nil)
+
+			respondError(c, "Failed to get menu", tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if want := `"code":"` + tt.wantCode + `"`; !strings.Contains(rec.Body.String(), want) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body.String(), want)
+			}
+			if logged := len(c.Errors) > 0; logged != tt.wantLogged {
+				t.Errorf("recorded errors = %v, want recorded %t", c.Errors, tt.wantLogged)
+			}
+		})
+	}
+}
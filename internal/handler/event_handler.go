@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"stk-technical-test-api/internal/event"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// heartbeatInterval keeps idle connections alive through proxies
+const heartbeatInterval = 15 * time.Second
+
+// streamWriteTimeout bounds each write to an event stream. It replaces the
+// server write timeout, which would otherwise cut every stream short.
+const streamWriteTimeout = 2 * heartbeatInterval
+
+type EventHandler struct {
+	broker *event.Broker
+}
+
+func NewEventHandler(broker *event.Broker) *EventHandler {
+	return &EventHandler{
+		broker: broker,
+	}
+}
+
+// StreamMenuEvents godoc
+// @Summary Stream menu changes
+// @Description Server-Sent Events stream of menu changes, resumable with Last-Event-ID
+// @Tags events
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "Resume after this event version"
+// @Success 200 {string} string "event stream"
+// @Router /api/menus/events [get]
+func (h *EventHandler) StreamMenuEvents(c *gin.Context) {
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	var lastVersion int64
+	if lastEventID != "" {
+		v, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || v < 0 {
+			c.String(http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+		lastVersion = v
+	}
+
+	sub := h.broker.Subscribe(lastVersion)
+	defer h.broker.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// Errors mean the writer has no deadline to extend, which is fine
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() {
+		_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	}
+	extendDeadline()
+
+	if sub.Gap {
+		// The requested version fell out of the buffer or predates a restart;
+		// tell the client to refetch
+		c.Render(-1, sse.Event{
+			Id:    strconv.FormatInt(h.broker.Version(), 10),
+			Event: "reset",
+			Data:  gin.H{"version": h.broker.Version()},
+		})
+	} else {
+		for _, e := range sub.Backlog {
+			c.Render(-1, sse.Event{
+				Id:    strconv.FormatInt(e.Version, 10),
+				Event: string(e.Type),
+				Data:  e,
+			})
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			extendDeadline()
+			if _, err := c.Writer.WriteString(": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case e, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			extendDeadline()
+			c.Render(-1, sse.Event{
+				Id:    strconv.FormatInt(e.Version, 10),
+				Event: string(e.Type),
+				Data:  e,
+			})
+			c.Writer.Flush()
+		}
+	}
+}
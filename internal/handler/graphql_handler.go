@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+type GraphQLHandler struct {
+	relay *relay.Handler
+}
+
+func NewGraphQLHandler(schema *graphql.Schema) *GraphQLHandler {
+	return &GraphQLHandler{
+		relay: &relay.Handler{Schema: schema},
+	}
+}
+
+// Query godoc
+// @Summary GraphQL endpoint
+// @Description Execute a GraphQL query or mutation against the menu schema
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Success 200 {object} object "GraphQL response with data and errors"
+// @Router /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	h.relay.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Component and overall statuses reported by the probes
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// HealthCheck is a dependency verified by the readiness probe
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// ComponentStatus is the result of one readiness check
+type ComponentStatus struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// ProbeResponse is the body of the liveness and readiness probes
+type ProbeResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+type HealthHandler struct {
+	timeout time.Duration
+	checks  []HealthCheck
+}
+
+// NewHealthHandler creates the probe handler. Each readiness check must
+// finish within timeout.
+func NewHealthHandler(timeout time.Duration, checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{
+		timeout: timeout,
+		checks:  checks,
+	}
+}
+
+// Health godoc
+// @Summary Health check
+// @Description Check if the server is running
+// @Tags health
+// @Produce json
+// @Success 200 {object} object
+// @Router /health [get]
+func Health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "Server Is Running",
+	})
+}
+
+// Livez godoc
+// @Summary Liveness probe
+// @Description Report that the process is running. Dependencies are not checked, so a database outage does not restart the server.
+// @Tags health
+// @Produce json
+// @Success 200 {object} handler.ProbeResponse
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, ProbeResponse{Status: StatusUp})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Check the database connection and schema version and report the status of each component
+// @Tags health
+// @Produce json
+// @Success 200 {object} handler.ProbeResponse
+// @Failure 503 {object} handler.ProbeResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	// Run the checks concurrently so one slow dependency does not hide another
+	results := make([]ComponentStatus, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	resp := ProbeResponse{
+		Status:     StatusUp,
+		Components: make(map[string]ComponentStatus, len(h.checks)),
+	}
+	for i, check := range h.checks {
+		resp.Components[check.Name] = results[i]
+		if results[i].Status != StatusUp {
+			resp.Status = StatusDown
+		}
+	}
+
+	status := http.StatusOK
+	if resp.Status != StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, resp)
+}
+
+func runCheck(ctx context.Context, check HealthCheck) ComponentStatus {
+	start := time.Now()
+	err := check.Check(ctx)
+
+	result := ComponentStatus{
+		Status:     StatusUp,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stk-technical-test-api/database/migrations"
+	"stk-technical-test-api/internal/config"
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/migration"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestReadyzReportsSchemaState(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		prepare    func(t *testing.T, db *gorm.DB, m *migration.Migrator)
+		wantStatus int
+		wantError  string
+		// wantNoSchema checks that the probe did not create the version table
+		wantNoSchema bool
+	}{
+		{
+			name: "current",
+			prepare: func(t *testing.T, db *gorm.DB, m *migration.Migrator) {
+				mustUp(t, m)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:         "not migrated",
+			prepare:      func(t *testing.T, db *gorm.DB, m *migration.Migrator) {},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantError:    "not migrated",
+			wantNoSchema: true,
+		},
+		{
+			// A failing query is reported as such, not as a missing schema
+			name: "database unreachable",
+			prepare: func(t *testing.T, db *gorm.DB, m *migration.Migrator) {
+				sqlDB, err := db.DB()
+				if err != nil {
+					t.Fatal(err)
+				}
+				sqlDB.Close()
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantError:  "failed to look up schema_migrations table",
+		},
+		{
+			name: "behind",
+			prepare: func(t *testing.T, db *gorm.DB, m *migration.Migrator) {
+				mustUp(t, m)
+				if _, err := m.Down(context.Background(), 1); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantError:  "is required",
+		},
+		{
+			name: "dirty",
+			prepare: func(t *testing.T, db *gorm.DB, m *migration.Migrator) {
+				mustUp(t, m)
+				if err := db.Exec("UPDATE schema_migrations SET dirty = ?", true).Error; err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantError:  "dirty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.OpenEmpty(t)
+			m, err := migration.New(db, migrations.FS, config.DriverSQLite)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.prepare(t, db, m)
+
+			router := gin.New()
+			router.GET("/readyz", NewHealthHandler(time.Second,
+				HealthCheck{Name: "migrations", Check: m.CheckCurrent},
+			).Readyz)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			var resp ProbeResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			got := resp.Components["migrations"]
+			if !strings.Contains(got.Error, tt.wantError) {
+				t.Errorf("error %q, want it to contain %q", got.Error, tt.wantError)
+			}
+
+			// The probe must never change the schema
+			if tt.wantNoSchema && db.Migrator().HasTable("schema_migrations") {
+				t.Error("readiness probe created the schema_migrations table")
+			}
+		})
+	}
+}
+
+func mustUp(t *testing.T, m *migration.Migrator) {
+	t.Helper()
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
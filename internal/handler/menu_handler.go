@@ -27,20 +27,23 @@ func NewMenuHandler(service domain.MenuService) *MenuHandler {
 // @Accept json
 // @Produce json
 // @Param menu body domain.CreateMenuRequest true "Menu data"
+// @Param Idempotency-Key header string false "Replays the first response to retries sent with the same key"
 // @Success 201 {object} response.Response
 // @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
 // @Router /api/menus [post]
 func (h *MenuHandler) CreateMenu(c *gin.Context) {
 	var req domain.CreateMenuRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	menu, err := h.service.CreateMenu(&req)
+	menu, err := h.service.CreateMenu(c.Request.Context(), &req)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Failed to create menu", err.Error())
+		respondError(c, "Failed to create menu", err)
 		return
 	}
 
@@ -56,9 +59,9 @@ func (h *MenuHandler) CreateMenu(c *gin.Context) {
 // @Failure 500 {object} response.Response
 // @Router /api/menus/hierarchy [get]
 func (h *MenuHandler) GetMenuHierarchy(c *gin.Context) {
-	menus, err := h.service.GetMenuHierarchy()
+	menus, err := h.service.GetMenuHierarchy(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get menu hierarchy", err.Error())
+		respondError(c, "Failed to get menu hierarchy", err)
 		return
 	}
 
@@ -74,9 +77,9 @@ func (h *MenuHandler) GetMenuHierarchy(c *gin.Context) {
 // @Failure 500 {object} response.Response
 // @Router /api/menus [get]
 func (h *MenuHandler) GetAllMenus(c *gin.Context) {
-	menus, err := h.service.GetAllMenus()
+	menus, err := h.service.GetAllMenus(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get menus", err.Error())
+		respondError(c, "Failed to get menus", err)
 		return
 	}
 
@@ -92,9 +95,9 @@ func (h *MenuHandler) GetAllMenus(c *gin.Context) {
 // @Failure 500 {object} response.Response
 // @Router /api/menus/root [get]
 func (h *MenuHandler) GetRootMenus(c *gin.Context) {
-	menus, err := h.service.GetRootMenus()
+	menus, err := h.service.GetRootMenus(c.Request.Context())
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get root menus", err.Error())
+		respondError(c, "Failed to get root menus", err)
 		return
 	}
 
@@ -110,17 +113,18 @@ func (h *MenuHandler) GetRootMenus(c *gin.Context) {
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 422 {object} response.Response
 // @Router /api/menus/{id}/hierarchy [get]
 func (h *MenuHandler) GetHierarchyByRootID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid menu ID", err.Error())
+		respondBadRequest(c, "Invalid menu ID", err.Error())
 		return
 	}
 
-	menus, err := h.service.GetHierarchyByRootID(id)
+	menus, err := h.service.GetHierarchyByRootID(c.Request.Context(), id)
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "Failed to get menu hierarchy", err.Error())
+		respondError(c, "Failed to get menu hierarchy", err)
 		return
 	}
 
@@ -140,13 +144,13 @@ func (h *MenuHandler) GetHierarchyByRootID(c *gin.Context) {
 func (h *MenuHandler) GetMenuDetail(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid menu ID", err.Error())
+		respondBadRequest(c, "Invalid menu ID", err.Error())
 		return
 	}
 
-	detail, err := h.service.GetMenuDetail(id)
+	detail, err := h.service.GetMenuDetail(c.Request.Context(), id)
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "Menu not found", err.Error())
+		respondError(c, "Failed to get menu", err)
 		return
 	}
 
@@ -166,13 +170,13 @@ func (h *MenuHandler) GetMenuDetail(c *gin.Context) {
 func (h *MenuHandler) GetChildrenByParentID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid parent ID", err.Error())
+		respondBadRequest(c, "Invalid parent ID", err.Error())
 		return
 	}
 
-	menus, err := h.service.GetChildrenByParentID(id)
+	menus, err := h.service.GetChildrenByParentID(c.Request.Context(), id)
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "Failed to get children", err.Error())
+		respondError(c, "Failed to get children", err)
 		return
 	}
 
@@ -186,18 +190,19 @@ func (h *MenuHandler) GetChildrenByParentID(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Menu ID"
 // @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Router /api/menus/{id} [get]
 func (h *MenuHandler) GetMenuByID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid menu ID", err.Error())
+		respondBadRequest(c, "Invalid menu ID", err.Error())
 		return
 	}
 
-	menu, err := h.service.GetMenuByID(id)
+	menu, err := h.service.GetMenuByID(c.Request.Context(), id)
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "Menu not found", err.Error())
+		respondError(c, "Failed to get menu", err)
 		return
 	}
 
@@ -211,18 +216,19 @@ func (h *MenuHandler) GetMenuByID(c *gin.Context) {
 // @Produce json
 // @Param uuid path string true "Menu UUID"
 // @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Router /api/menus/uuid/{uuid} [get]
 func (h *MenuHandler) GetMenuByUUID(c *gin.Context) {
 	uuid := c.Param("uuid")
 	if uuid == "" {
-		response.Error(c, http.StatusBadRequest, "Invalid menu UUID", "UUID is required")
+		respondBadRequest(c, "Invalid menu UUID", "UUID is required")
 		return
 	}
 
-	menu, err := h.service.GetMenuByUUID(uuid)
+	menu, err := h.service.GetMenuByUUID(c.Request.Context(), uuid)
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "Menu not found", err.Error())
+		respondError(c, "Failed to get menu", err)
 		return
 	}
 
@@ -239,30 +245,69 @@ func (h *MenuHandler) GetMenuByUUID(c *gin.Context) {
 // @Param menu body domain.UpdateMenuRequest true "Menu data"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
 // @Router /api/menus/{id} [put]
 func (h *MenuHandler) UpdateMenu(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid menu ID", err.Error())
+		respondBadRequest(c, "Invalid menu ID", err.Error())
 		return
 	}
 
 	var req domain.UpdateMenuRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	menu, err := h.service.UpdateMenu(id, &req)
+	menu, err := h.service.UpdateMenu(c.Request.Context(), id, &req)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Failed to update menu", err.Error())
+		respondError(c, "Failed to update menu", err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "Menu updated successfully", menu)
 }
 
+// CloneMenu godoc
+// @Summary Clone a menu subtree
+// @Description Copy a menu and all its descendants under a new parent with new UUIDs, levels and rewritten codes. Code collisions are reported before anything is written
+// @Tags menus
+// @Accept json
+// @Produce json
+// @Param id path int true "Menu ID"
+// @Param clone body domain.CloneMenuRequest true "Target parent and code rewrite rules"
+// @Param Idempotency-Key header string false "Replays the first response to retries sent with the same key"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/menus/{id}/clone [post]
+func (h *MenuHandler) CloneMenu(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid menu ID", err.Error())
+		return
+	}
+
+	var req domain.CloneMenuRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	menu, err := h.service.CloneMenu(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, "Failed to clone menu", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Menu cloned successfully", menu)
+}
+
 // DeleteMenu godoc
 // @Summary Delete a menu
 // @Description Delete a menu by ID
@@ -272,20 +317,20 @@ func (h *MenuHandler) UpdateMenu(c *gin.Context) {
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
 // @Router /api/menus/{id} [delete]
 func (h *MenuHandler) DeleteMenu(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid menu ID", err.Error())
+		respondBadRequest(c, "Invalid menu ID", err.Error())
 		return
 	}
 
-	err = h.service.DeleteMenu(id)
+	err = h.service.DeleteMenu(c.Request.Context(), id)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Failed to delete menu", err.Error())
+		respondError(c, "Failed to delete menu", err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "Menu deleted successfully", nil)
 }
-
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"net/http"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MenuTemplateHandler struct {
+	service domain.MenuTemplateService
+}
+
+func NewMenuTemplateHandler(service domain.MenuTemplateService) *MenuTemplateHandler {
+	return &MenuTemplateHandler{
+		service: service,
+	}
+}
+
+// CreateMenuTemplate godoc
+// @Summary Create a menu template
+// @Description Store a named menu tree blueprint. Name, code and route of its menus may contain {{variable}} placeholders
+// @Tags menu-templates
+// @Accept json
+// @Produce json
+// @Param template body domain.CreateMenuTemplateRequest true "Template data"
+// @Param Idempotency-Key header string false "Replays the first response to retries sent with the same key"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/menu-templates [post]
+func (h *MenuTemplateHandler) CreateMenuTemplate(c *gin.Context) {
+	var req domain.CreateMenuTemplateRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	tmpl, err := h.service.CreateTemplate(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, "Failed to create menu template", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Menu template created successfully", tmpl)
+}
+
+// GetAllMenuTemplates godoc
+// @Summary Get all menu templates
+// @Description Get all menu templates ordered by name
+// @Tags menu-templates
+// @Produce json
+// @Success 200 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/menu-templates [get]
+func (h *MenuTemplateHandler) GetAllMenuTemplates(c *gin.Context) {
+	templates, err := h.service.GetAllTemplates(c.Request.Context())
+	if err != nil {
+		respondError(c, "Failed to get menu templates", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Menu templates retrieved successfully", templates)
+}
+
+// GetMenuTemplate godoc
+// @Summary Get a menu template
+// @Description Get a menu template and the variables it uses by name
+// @Tags menu-templates
+// @Produce json
+// @Param name path string true "Template name"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/menu-templates/{name} [get]
+func (h *MenuTemplateHandler) GetMenuTemplate(c *gin.Context) {
+	tmpl, err := h.service.GetTemplate(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		respondError(c, "Failed to get menu template", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Menu template retrieved successfully", tmpl)
+}
+
+// UpdateMenuTemplate godoc
+// @Summary Update a menu template
+// @Description Replace the name, description and tree of a menu template. Menus created from it are not changed
+// @Tags menu-templates
+// @Accept json
+// @Produce json
+// @Param name path string true "Template name"
+// @Param template body domain.UpdateMenuTemplateRequest true "Template data"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/menu-templates/{name} [put]
+func (h *MenuTemplateHandler) UpdateMenuTemplate(c *gin.Context) {
+	var req domain.UpdateMenuTemplateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	tmpl, err := h.service.UpdateTemplate(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		respondError(c, "Failed to update menu template", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Menu template updated successfully", tmpl)
+}
+
+// DeleteMenuTemplate godoc
+// @Summary Delete a menu template
+// @Description Delete a menu template. Menus created from it are kept
+// @Tags menu-templates
+// @Produce json
+// @Param name path string true "Template name"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/menu-templates/{name} [delete]
+func (h *MenuTemplateHandler) DeleteMenuTemplate(c *gin.Context) {
+	err := h.service.DeleteTemplate(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		respondError(c, "Failed to delete menu template", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Menu template deleted successfully", nil)
+}
+
+// InstantiateMenuTemplate godoc
+// @Summary Create menus from a template
+// @Description Fill in the template variables and create its menu tree under a parent in one transaction. Every problem, including taken codes, is reported before anything is written
+// @Tags menu-templates
+// @Accept json
+// @Produce json
+// @Param name path string true "Template name"
+// @Param instantiate body domain.InstantiateMenuTemplateRequest true "Target parent and variable values"
+// @Param Idempotency-Key header string false "Replays the first response to retries sent with the same key"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/menu-templates/{name}/instantiate [post]
+func (h *MenuTemplateHandler) InstantiateMenuTemplate(c *gin.Context) {
+	var req domain.InstantiateMenuTemplateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	menu, err := h.service.InstantiateTemplate(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		respondError(c, "Failed to instantiate menu template", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Menus created from template successfully", menu)
+}
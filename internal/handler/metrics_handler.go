@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MetricsHandler struct {
+	handler http.Handler
+}
+
+func NewMetricsHandler(handler http.Handler) *MetricsHandler {
+	return &MetricsHandler{
+		handler: handler,
+	}
+}
+
+// GetMetrics godoc
+// @Summary Prometheus metrics
+// @Description Request, database pool and menu metrics in the Prometheus text format
+// @Tags metrics
+// @Produce text
+// @Success 200 {string} string "metrics"
+// @Router /metrics [get]
+func (h *MetricsHandler) GetMetrics(c *gin.Context) {
+	h.handler.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	service domain.WebhookService
+}
+
+func NewWebhookHandler(service domain.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Description Subscribe a URL to menu change events
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body domain.CreateWebhookRequest true "Webhook data"
+// @Param Idempotency-Key header string false "Replays the first response to retries sent with the same key"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req domain.CreateWebhookRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	sub, err := h.service.CreateSubscription(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, "Failed to create webhook", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Webhook created successfully", sub)
+}
+
+// GetAllWebhooks godoc
+// @Summary Get all webhook subscriptions
+// @Description Get all webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/webhooks [get]
+func (h *WebhookHandler) GetAllWebhooks(c *gin.Context) {
+	subs, err := h.service.GetAllSubscriptions(c.Request.Context())
+	if err != nil {
+		respondError(c, "Failed to get webhooks", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Webhooks retrieved successfully", subs)
+}
+
+// GetWebhookByID godoc
+// @Summary Get webhook subscription by ID
+// @Description Get a single webhook subscription by ID
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	sub, err := h.service.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Failed to get webhook", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Webhook retrieved successfully", sub)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook subscription
+// @Description Update an existing webhook subscription
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param webhook body domain.UpdateWebhookRequest true "Webhook data"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Router /api/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	var req domain.UpdateWebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	sub, err := h.service.UpdateSubscription(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, "Failed to update webhook", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Webhook updated successfully", sub)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Description Delete a webhook subscription and its delivery log
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	err = h.service.DeleteSubscription(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Failed to delete webhook", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get webhook delivery log
+// @Description Get the most recent deliveries of a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param limit query int false "Maximum number of deliveries (default 100)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondBadRequest(c, "Invalid webhook ID", err.Error())
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), id, limit)
+	if err != nil {
+		respondError(c, "Failed to get deliveries", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Deliveries retrieved successfully", deliveries)
+}
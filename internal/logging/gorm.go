@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// gormLogger writes GORM output to slog. Every statement is logged at debug
+// level, statements slower than slowThreshold as warnings and failed ones as
+// errors, each with the request ID of the query context. Statements are
+// logged with their placeholders, never with the values bound to them, so
+// secrets and personal data stay out of the logs.
+type gormLogger struct {
+	logger        *slog.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGormLogger creates a GORM logger backed by l. A zero slowThreshold
+// disables the slow-query log.
+func NewGormLogger(l *slog.Logger, slowThreshold time.Duration) logger.Interface {
+	return &gormLogger{
+		logger:        l,
+		level:         logger.Info,
+		slowThreshold: slowThreshold,
+	}
+}
+
+func (g *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *g
+	clone.level = level
+	return &clone
+}
+
+func (g *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= logger.Info {
+		g.logger.InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= logger.Warn {
+		g.logger.WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= logger.Error {
+		g.logger.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.level <= logger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	var (
+		level slog.Level
+		msg   string
+	)
+	switch {
+	// Missing rows and duplicate keys are reported to the client as 404s
+	// and 409s, not failures
+	case err != nil && !expectedError(err) && g.level >= logger.Error:
+		level, msg = slog.LevelError, "query failed"
+	case g.slowThreshold > 0 && elapsed > g.slowThreshold && g.level >= logger.Warn:
+		level, msg = slog.LevelWarn, "slow query"
+	case g.level >= logger.Info:
+		level, msg = slog.LevelDebug, "query"
+	default:
+		return
+	}
+	if !g.logger.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if rows >= 0 {
+		attrs = append(attrs, slog.Int64("rows", rows))
+	}
+	if level == slog.LevelWarn {
+		attrs = append(attrs, slog.String("threshold", g.slowThreshold.String()))
+	}
+	if level == slog.LevelError {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	g.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter implements gorm.ParamsFilter. Dropping the values leaves the
+// placeholders in the logged SQL.
+func (g *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// expectedError reports whether err is an outcome the repositories turn
+// into a client error
+func expectedError(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,102 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/repository"
+)
+
+// captureLogs sends the default logger to a buffer at debug level until the
+// test ends and returns the buffer
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+// records decodes the JSON log lines in buf
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+func TestGormLoggerKeepsValuesOutOfLogs(t *testing.T) {
+	buf := captureLogs(t)
+	db := dbtest.Open(t)
+	ctx := context.Background()
+
+	const secret = "do-not-log-this-secret"
+	repo := repository.NewWebhookRepository(db)
+	err := repo.CreateSubscription(ctx, &domain.WebhookSubscription{URL: "https://example.com/hook", Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A failing statement is logged as an error, without its values
+	err = db.Exec("INSERT INTO missing_table (secret) VALUES (?)", secret).Error
+	if err == nil {
+		t.Fatal("insert into a missing table succeeded")
+	}
+
+	if strings.Contains(buf.String(), secret) {
+		t.Errorf("logs contain a bound value:\n%s", buf)
+	}
+
+	var queries, failures int
+	for _, rec := range records(t, buf) {
+		switch rec["msg"] {
+		case "query":
+			queries++
+		case "query failed":
+			failures++
+			if sql, _ := rec["sql"].(string); !strings.Contains(sql, "VALUES (?)") {
+				t.Errorf("failed query logged as %q, want its placeholders", sql)
+			}
+		}
+	}
+	if queries == 0 || failures != 1 {
+		t.Errorf("logged %d queries and %d failures, want some queries and 1 failure", queries, failures)
+	}
+}
+
+func TestGormLoggerSkipsExpectedErrors(t *testing.T) {
+	buf := captureLogs(t)
+	db := dbtest.Open(t)
+	ctx := context.Background()
+	repo := repository.NewMenuRepository(db)
+
+	if err := repo.Create(ctx, &domain.Menu{Name: "Users", Code: "users"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(ctx, &domain.Menu{Name: "Users", Code: "users"}); err == nil {
+		t.Fatal("duplicate code was stored")
+	}
+	if _, err := repo.FindByID(ctx, 999); err == nil {
+		t.Fatal("missing menu was found")
+	}
+
+	for _, rec := range records(t, buf) {
+		if rec["level"] == "ERROR" {
+			t.Errorf("expected error logged as a failure: %v", rec)
+		}
+	}
+}
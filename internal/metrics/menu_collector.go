@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// menuCollector counts the menus by active state on every scrape
+type menuCollector struct {
+	repo    domain.MenuRepository
+	timeout time.Duration
+	menus   *prometheus.Desc
+}
+
+// RegisterMenuCounts exposes the number of menus by active state, read from
+// repo with the given timeout whenever the metrics are scraped
+func (m *Metrics) RegisterMenuCounts(repo domain.MenuRepository, timeout time.Duration) {
+	m.registry.MustRegister(&menuCollector{
+		repo:    repo,
+		timeout: timeout,
+		menus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "menus"),
+			"Number of menus by active state.",
+			[]string{"active"}, nil,
+		),
+	})
+}
+
+func (c *menuCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.menus
+}
+
+func (c *menuCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	counts, err := c.repo.CountByActive(ctx)
+	if err != nil {
+		// Report the failure instead of exporting stale or zero counts
+		slog.Error("Failed to count menus for metrics", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.menus, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.menus, prometheus.GaugeValue, float64(counts.Active), "true")
+	ch <- prometheus.MustNewConstMetric(c.menus, prometheus.GaugeValue, float64(counts.Inactive), "false")
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// instrumentedMenuRepository times every call of the wrapped repository
+type instrumentedMenuRepository struct {
+	next    domain.MenuRepository
+	metrics *Metrics
+}
+
+// NewMenuRepository wraps repo so that every call is recorded in
+// repository_query_duration_seconds and tree loads also in
+// menu_hierarchy_build_duration_seconds
+func NewMenuRepository(repo domain.MenuRepository, m *Metrics) domain.MenuRepository {
+	return &instrumentedMenuRepository{
+		next:    repo,
+		metrics: m,
+	}
+}
+
+// observe records a call started at start; err points at the named result
+// so the deferred call sees its final value
+func (r *instrumentedMenuRepository) observe(method string, start time.Time, err *error) {
+	r.metrics.observeQuery(method, start, *err)
+}
+
+func (r *instrumentedMenuRepository) Create(ctx context.Context, menu *domain.Menu) (err error) {
+	defer r.observe("Create", time.Now(), &err)
+	return r.next.Create(ctx, menu)
+}
+
+func (r *instrumentedMenuRepository) Update(ctx context.Context, menu *domain.Menu) (err error) {
+	defer r.observe("Update", time.Now(), &err)
+	return r.next.Update(ctx, menu)
+}
+
+func (r *instrumentedMenuRepository) Delete(ctx context.Context, id int64) (err error) {
+	defer r.observe("Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedMenuRepository) FindByID(ctx context.Context, id int64) (result *domain.Menu, err error) {
+	defer r.observe("FindByID", time.Now(), &err)
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *instrumentedMenuRepository) FindByIDForUpdate(ctx context.Context, id int64) (result *domain.Menu, err error) {
+	defer r.observe("FindByIDForUpdate", time.Now(), &err)
+	return r.next.FindByIDForUpdate(ctx, id)
+}
+
+func (r *instrumentedMenuRepository) FindByUUID(ctx context.Context, uuid string) (result *domain.Menu, err error) {
+	defer r.observe("FindByUUID", time.Now(), &err)
+	return r.next.FindByUUID(ctx, uuid)
+}
+
+func (r *instrumentedMenuRepository) FindByCodes(ctx context.Context, codes []string) (result []domain.Menu, err error) {
+	defer r.observe("FindByCodes", time.Now(), &err)
+	return r.next.FindByCodes(ctx, codes)
+}
+
+func (r *instrumentedMenuRepository) FindByIDs(ctx context.Context, ids []int64) (result []domain.Menu, err error) {
+	defer r.observe("FindByIDs", time.Now(), &err)
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *instrumentedMenuRepository) FindAll(ctx context.Context) (result []domain.Menu, err error) {
+	defer r.observe("FindAll", time.Now(), &err)
+	return r.next.FindAll(ctx)
+}
+
+func (r *instrumentedMenuRepository) FindByParentID(ctx context.Context, parentID *int64) (result []domain.Menu, err error) {
+	defer r.observe("FindByParentID", time.Now(), &err)
+	return r.next.FindByParentID(ctx, parentID)
+}
+
+func (r *instrumentedMenuRepository) FindRootMenus(ctx context.Context) (result []domain.Menu, err error) {
+	defer r.observe("FindRootMenus", time.Now(), &err)
+	return r.next.FindRootMenus(ctx)
+}
+
+func (r *instrumentedMenuRepository) FindHierarchical(ctx context.Context) (result []domain.Menu, err error) {
+	defer r.observe("FindHierarchical", time.Now(), &err)
+	defer r.metrics.observeHierarchy("all", time.Now())
+	return r.next.FindHierarchical(ctx)
+}
+
+func (r *instrumentedMenuRepository) FindHierarchicalByRootID(ctx context.Context, rootID int64) (result []domain.Menu, err error) {
+	defer r.observe("FindHierarchicalByRootID", time.Now(), &err)
+	defer r.metrics.observeHierarchy("root", time.Now())
+	return r.next.FindHierarchicalByRootID(ctx, rootID)
+}
+
+func (r *instrumentedMenuRepository) FindDetailByID(ctx context.Context, id int64) (result *domain.MenuDetail, err error) {
+	defer r.observe("FindDetailByID", time.Now(), &err)
+	return r.next.FindDetailByID(ctx, id)
+}
+
+func (r *instrumentedMenuRepository) FindChildrenByParentID(ctx context.Context, parentID int64) (result []domain.Menu, err error) {
+	defer r.observe("FindChildrenByParentID", time.Now(), &err)
+	return r.next.FindChildrenByParentID(ctx, parentID)
+}
+
+func (r *instrumentedMenuRepository) FindChildrenByParentIDs(ctx context.Context, parentIDs []int64) (result []domain.Menu, err error) {
+	defer r.observe("FindChildrenByParentIDs", time.Now(), &err)
+	return r.next.FindChildrenByParentIDs(ctx, parentIDs)
+}
+
+func (r *instrumentedMenuRepository) Search(ctx context.Context, query string, limit int) (result []domain.Menu, err error) {
+	defer r.observe("Search", time.Now(), &err)
+	return r.next.Search(ctx, query, limit)
+}
+
+func (r *instrumentedMenuRepository) CountByActive(ctx context.Context) (result *domain.MenuCounts, err error) {
+	defer r.observe("CountByActive", time.Now(), &err)
+	return r.next.CountByActive(ctx)
+}
+
+func (r *instrumentedMenuRepository) EnqueueEvent(ctx context.Context, event *domain.OutboxEvent) (err error) {
+	defer r.observe("EnqueueEvent", time.Now(), &err)
+	return r.next.EnqueueEvent(ctx, event)
+}
+
+// WithTx instruments the transaction as a whole and the repository passed to
+// fn, so calls inside the transaction are recorded too
+func (r *instrumentedMenuRepository) WithTx(ctx context.Context, fn func(repo domain.MenuRepository) error) (err error) {
+	defer r.observe("WithTx", time.Now(), &err)
+	return r.next.WithTx(ctx, func(repo domain.MenuRepository) error {
+		return fn(NewMenuRepository(repo, r.metrics))
+	})
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit rejects request bodies larger than limit bytes with 413. Bodies
+// that announce their size are checked up front; others fail while being
+// read. A zero limit leaves bodies unbounded.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			response.Error(c, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge, "Request body too large",
+				fmt.Sprintf("request body exceeds %d bytes", limit))
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Idempotency headers
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength matches the size of the stored key
+const maxIdempotencyKeyLength = 255
+
+// Idempotency makes retries of a request carrying an Idempotency-Key safe.
+// The first response is stored for ttl per principal, or else per client
+// IP, and replayed to retries with the same method, path and body, so an
+// authenticated client that changed networks still gets it. A retry with a
+// different request gets 422, one arriving while the first request is still
+// running gets 409. Server errors are not stored, so the request can be
+// retried. A running request older than abandonAfter is assumed lost and
+// its key freed. Requests without the header are not affected.
+func Idempotency(store domain.IdempotencyRepository, ttl, abandonAfter time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if !printableASCII(key, maxIdempotencyKeyLength) {
+			response.Error(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid Idempotency-Key",
+				fmt.Sprintf("the key must be 1 to %d printable ASCII characters", maxIdempotencyKeyLength))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				response.Error(c, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge, "Request body too large",
+					fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+			} else {
+				response.Error(c, http.StatusBadRequest, response.CodeInvalidRequest, "Invalid request", err.Error())
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		rec := &domain.IdempotencyRecord{
+			Scope:       clientKey(c),
+			Key:         key,
+			RequestHash: requestHash(c.Request.Method, c.Request.URL.Path, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		ctx := c.Request.Context()
+		existing, err := store.Reserve(ctx, rec, now.Add(-abandonAfter))
+		if err != nil {
+			_ = c.Error(err)
+			response.Error(c, http.StatusInternalServerError, response.CodeInternalError, "Failed to check Idempotency-Key", nil)
+			c.Abort()
+			return
+		}
+
+		switch {
+		case existing == nil:
+		case existing.RequestHash != rec.RequestHash:
+			response.Error(c, http.StatusUnprocessableEntity, response.CodeIdempotencyKeyReused, "Idempotency-Key reused",
+				"the key was already used for a different request")
+			c.Abort()
+			return
+		case !existing.Completed():
+			c.Header(RetryAfterHeader, "1")
+			response.Error(c, http.StatusConflict, response.CodeIdempotencyKeyInProgress, "Request in progress",
+				"a request with this Idempotency-Key is still being processed")
+			c.Abort()
+			return
+		default:
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(existing.StatusCode, existing.ContentType, []byte(existing.ResponseBody))
+			c.Abort()
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// The request context may have expired; storing the outcome must not
+		// fail because of it
+		ctx = context.WithoutCancel(ctx)
+		if status := writer.Status(); status < http.StatusInternalServerError {
+			rec.StatusCode = status
+			rec.ContentType = writer.Header().Get("Content-Type")
+			rec.ResponseBody = writer.body.String()
+			err := store.Complete(ctx, rec)
+			if err == nil {
+				return
+			}
+			slog.ErrorContext(ctx, "Failed to store idempotent response", "error", err)
+		}
+		if err := store.Release(ctx, rec.ID); err != nil {
+			slog.ErrorContext(ctx, "Failed to release Idempotency-Key", "error", err)
+		}
+	}
+}
+
+// requestHash fingerprints the parts of a request a retry must repeat
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", method, path)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter keeps a copy of the response body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
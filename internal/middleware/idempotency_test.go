@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idempotencyTest serves POST /items behind the idempotency middleware. The
+// handler answers 201 with a running count of how often it ran.
+type idempotencyTest struct {
+	router *gin.Engine
+	calls  atomic.Int32
+	// block, when set, holds the handler until it is closed
+	block   chan struct{}
+	started chan struct{}
+}
+
+func newIdempotencyTest(t *testing.T, ttl time.Duration) *idempotencyTest {
+	gin.SetMode(gin.TestMode)
+
+	it := &idempotencyTest{router: gin.New()}
+	it.router.Use(func(c *gin.Context) {
+		if principal := c.GetHeader(principalHeader); principal != "" {
+			c.Set(PrincipalKey, principal)
+		}
+	})
+	store := repository.NewIdempotencyRepository(dbtest.Open(t))
+	it.router.POST("/items", Idempotency(store, ttl, time.Minute), func(c *gin.Context) {
+		n := it.calls.Add(1)
+		if it.block != nil {
+			it.started <- struct{}{}
+			<-it.block
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": n})
+	})
+	return it
+}
+
+func (it *idempotencyTest) post(key, body string) *httptest.ResponseRecorder {
+	return it.postAs("192.0.2.1", "", key, body)
+}
+
+// postAs sends the request from addr on behalf of principal, if not empty
+func (it *idempotencyTest) postAs(addr, principal, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.RemoteAddr = addr + ":1234"
+	req.Header.Set("Content-Type", "application/json")
+	if principal != "" {
+		req.Header.Set(principalHeader, principal)
+	}
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	it.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func assertResponse(t *testing.T, name string, rec *httptest.ResponseRecorder, status int, contains string) {
+	t.Helper()
+	if rec.Code != status || !strings.Contains(rec.Body.String(), contains) {
+		t.Errorf("%s: %d %s, want %d containing %q", name, rec.Code, rec.Body, status, contains)
+	}
+}
+
+func TestIdempotencyReplaysAndRejectsReuse(t *testing.T) {
+	it := newIdempotencyTest(t, time.Hour)
+
+	assertResponse(t, "first", it.post("key-1", `{"name":"a"}`), http.StatusCreated, `"call":1`)
+
+	replay := it.post("key-1", `{"name":"a"}`)
+	assertResponse(t, "retry", replay, http.StatusCreated, `"call":1`)
+	if replay.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("retry is not marked as replayed")
+	}
+
+	assertResponse(t, "different body", it.post("key-1", `{"name":"b"}`),
+		http.StatusUnprocessableEntity, "idempotency_key_reused")
+	assertResponse(t, "other key", it.post("key-2", `{"name":"a"}`), http.StatusCreated, `"call":2`)
+	assertResponse(t, "no key", it.post("", `{"name":"a"}`), http.StatusCreated, `"call":3`)
+	assertResponse(t, "invalid key", it.post("bad\x01key", `{}`), http.StatusBadRequest, "invalid_request")
+
+	if got := it.calls.Load(); got != 3 {
+		t.Errorf("handler ran %d times, want 3", got)
+	}
+}
+
+func TestIdempotencyRejectsConcurrentRetry(t *testing.T) {
+	it := newIdempotencyTest(t, time.Hour)
+	it.block = make(chan struct{})
+	it.started = make(chan struct{})
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- it.post("key-1", `{"name":"a"}`) }()
+	<-it.started
+
+	// The first request holds the key until it completes
+	retry := it.post("key-1", `{"name":"a"}`)
+	assertResponse(t, "concurrent retry", retry, http.StatusConflict, "idempotency_key_in_progress")
+	if retry.Header().Get(RetryAfterHeader) == "" {
+		t.Error("concurrent retry has no Retry-After header")
+	}
+
+	close(it.block)
+	assertResponse(t, "first", <-first, http.StatusCreated, `"call":1`)
+
+	it.block = nil
+	assertResponse(t, "retry after completion", it.post("key-1", `{"name":"a"}`), http.StatusCreated, `"call":1`)
+}
+
+func TestIdempotencyKeyExpires(t *testing.T) {
+	ttl := 50 * time.Millisecond
+	it := newIdempotencyTest(t, ttl)
+
+	assertResponse(t, "first", it.post("key-1", `{"name":"a"}`), http.StatusCreated, `"call":1`)
+	assertResponse(t, "retry", it.post("key-1", `{"name":"a"}`), http.StatusCreated, `"call":1`)
+
+	time.Sleep(2 * ttl)
+
+	// An expired key is free again, even for a different request
+	rec := it.post("key-1", `{"name":"b"}`)
+	assertResponse(t, "after expiry", rec, http.StatusCreated, `"call":2`)
+	if rec.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("request after expiry was replayed: %s", rec.Body)
+	}
+}
+
+func TestIdempotencyScopesByPrincipal(t *testing.T) {
+	it := newIdempotencyTest(t, time.Hour)
+
+	assertResponse(t, "first", it.postAs("192.0.2.1", "alice", "key-1", `{"name":"a"}`), http.StatusCreated, `"call":1`)
+
+	// A client that changed networks still gets its stored response
+	assertResponse(t, "retry from another address", it.postAs("198.51.100.7", "alice", "key-1", `{"name":"a"}`),
+		http.StatusCreated, `"call":1`)
+
+	// Clients behind one address do not see each other's responses
+	assertResponse(t, "other principal", it.postAs("192.0.2.1", "bob", "key-1", `{"name":"a"}`),
+		http.StatusCreated, `"call":2`)
+	assertResponse(t, "anonymous", it.postAs("192.0.2.1", "", "key-1", `{"name":"a"}`),
+		http.StatusCreated, `"call":3`)
+	assertResponse(t, "anonymous from another address", it.postAs("198.51.100.7", "", "key-1", `{"name":"a"}`),
+		http.StatusCreated, `"call":4`)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"stk-technical-test-api/internal/ratelimit"
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrincipalKey is the Gin context key under which authentication middleware
+// stores the authenticated principal. Requests without one are limited by
+// client IP.
+const PrincipalKey = "principal"
+
+// Rate limit response headers
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimit counts safe requests (GET, HEAD, OPTIONS) against reads and all
+// others against writes, per authenticated principal or else per client IP.
+// Every response carries the RateLimit-* headers of the budget used; requests
+// over budget get 429 with Retry-After.
+func RateLimit(reads, writes *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := writes
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			limiter = reads
+		}
+
+		result := limiter.Allow(clientKey(c))
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(RateLimitResetHeader, seconds(result.Reset))
+		c.Header(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%s", limiter.Limit(), seconds(limiter.Window())))
+
+		if !result.Allowed {
+			c.Header(RetryAfterHeader, seconds(result.RetryAfter))
+			response.Error(c, http.StatusTooManyRequests, response.CodeRateLimited, "Too many requests",
+				fmt.Sprintf("rate limit of %d requests per %s exceeded", limiter.Limit(), limiter.Window()))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientKey identifies the bucket of the request. Principals and addresses
+// use separate key spaces so a principal named like an IP cannot share its
+// budget.
+func clientKey(c *gin.Context) string {
+	if principal := c.GetString(PrincipalKey); principal != "" {
+		return "principal:" + principal
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// seconds formats d as whole seconds, rounded up so clients never retry early
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
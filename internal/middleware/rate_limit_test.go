@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stk-technical-test-api/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalHeader stands in for authentication in these tests: its value
+// becomes the principal of the request
+const principalHeader = "X-Test-Principal"
+
+func newRateLimitRouter(reads, writes int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if principal := c.GetHeader(principalHeader); principal != "" {
+			c.Set(PrincipalKey, principal)
+		}
+	})
+	router.Use(RateLimit(ratelimit.New(reads, time.Minute), ratelimit.New(writes, time.Minute)))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/items", ok)
+	router.POST("/items", ok)
+	return router
+}
+
+func sendFrom(router *gin.Engine, method, addr string) *httptest.ResponseRecorder {
+	return sendAs(router, method, addr, "")
+}
+
+// sendAs sends a request from addr on behalf of principal, if not empty
+func sendAs(router *gin.Engine, method, addr, principal string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/items", nil)
+	req.RemoteAddr = addr + ":1234"
+	if principal != "" {
+		req.Header.Set(principalHeader, principal)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	router := newRateLimitRouter(3, 1)
+
+	rec := sendFrom(router, http.MethodGet, "192.0.2.1")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	want := map[string]string{
+		RateLimitLimitHeader:     "3",
+		RateLimitRemainingHeader: "2",
+		RateLimitResetHeader:     "20",
+		RateLimitPolicyHeader:    "3;w=60",
+	}
+	for header, value := range want {
+		if got := rec.Header().Get(header); got != value {
+			t.Errorf("%s = %q, want %q", header, got, value)
+		}
+	}
+	if got := rec.Header().Get(RetryAfterHeader); got != "" {
+		t.Errorf("%s = %q on an allowed request", RetryAfterHeader, got)
+	}
+}
+
+func TestRateLimitRejectsOverBudget(t *testing.T) {
+	router := newRateLimitRouter(3, 2)
+
+	for i := 0; i < 2; i++ {
+		if rec := sendFrom(router, http.MethodPost, "192.0.2.1"); rec.Code != http.StatusNoContent {
+			t.Fatalf("write %d: status = %d", i+1, rec.Code)
+		}
+	}
+
+	rec := sendFrom(router, http.MethodPost, "192.0.2.1")
+	assertResponse(t, "write over budget", rec, http.StatusTooManyRequests, `"code":"rate_limited"`)
+	if got := rec.Header().Get(RetryAfterHeader); got != "30" {
+		t.Errorf("%s = %q, want %q", RetryAfterHeader, got, "30")
+	}
+	if got := rec.Header().Get(RateLimitRemainingHeader); got != "0" {
+		t.Errorf("%s = %q, want %q", RateLimitRemainingHeader, got, "0")
+	}
+}
+
+func TestRateLimitSeparatesBudgets(t *testing.T) {
+	router := newRateLimitRouter(2, 1)
+
+	if rec := sendFrom(router, http.MethodPost, "192.0.2.1"); rec.Code != http.StatusNoContent {
+		t.Fatalf("write: status = %d", rec.Code)
+	}
+	if rec := sendFrom(router, http.MethodPost, "192.0.2.1"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second write: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+
+	// Exhausting the write budget leaves reads and other clients alone
+	tests := []struct {
+		name   string
+		method string
+		addr   string
+		want   int
+	}{
+		{name: "read of the same client", method: http.MethodGet, addr: "192.0.2.1", want: http.StatusNoContent},
+		{name: "write of another client", method: http.MethodPost, addr: "192.0.2.2", want: http.StatusNoContent},
+		{name: "read of another client", method: http.MethodGet, addr: "192.0.2.2", want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		if rec := sendFrom(router, tt.method, tt.addr); rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestRateLimitKeysByPrincipal(t *testing.T) {
+	router := newRateLimitRouter(2, 1)
+
+	// Two principals behind one address each get their own budget
+	for _, principal := range []string{"alice", "bob"} {
+		if rec := sendAs(router, http.MethodPost, "192.0.2.1", principal); rec.Code != http.StatusNoContent {
+			t.Fatalf("write of %s: status = %d", principal, rec.Code)
+		}
+	}
+	if rec := sendAs(router, http.MethodPost, "192.0.2.1", "alice"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("second write of alice: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+
+	// A principal keeps its budget from another address
+	if rec := sendAs(router, http.MethodPost, "192.0.2.2", "bob"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("write of bob from another address: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+
+	// Anonymous requests use the address budget, which the principals did not touch
+	if rec := sendFrom(router, http.MethodPost, "192.0.2.1"); rec.Code != http.StatusNoContent {
+		t.Errorf("anonymous write: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// A principal named like an address does not share the address budget
+	if rec := sendAs(router, http.MethodPost, "192.0.2.3", "192.0.2.1"); rec.Code != http.StatusNoContent {
+		t.Errorf("write of principal 192.0.2.1: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"stk-technical-test-api/internal/logging"
+	"stk-technical-test-api/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID in requests and responses
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client supplied IDs so they cannot bloat the logs
+const maxRequestIDLength = 128
+
+// RequestID takes the request ID from the X-Request-ID header, or generates
+// one when it is missing or malformed, and echoes it in the response. The ID
+// is stored in the request context for the logs and in the Gin context for
+// response bodies.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !printableASCII(id, maxRequestIDLength) {
+			id = uuid.NewString()
+		}
+
+		c.Set(response.RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// printableASCII accepts non-empty header values of at most maxLen
+// printable ASCII characters
+func printableASCII(s string, maxLen int) bool {
+	if s == "" || len(s) > maxLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Names of the migration lock. The PostgreSQL key is an arbitrary constant,
+// "smig" in ASCII.
+const (
+	mysqlLockName  = "schema_migrations"
+	postgresLockID = 0x736d6967
+)
+
+// lock takes a database wide advisory lock so that replicas starting at the
+// same time apply each migration once. The lock belongs to a connection that
+// is reserved until unlock is called. SQLite has no advisory locks, so a
+// SQLite database must only be migrated by one process at a time.
+func (m *Migrator) lock(ctx context.Context) (unlock func(), err error) {
+	driver := m.db.Dialector.Name()
+	if driver != "mysql" && driver != "postgres" {
+		return func() {}, nil
+	}
+
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve a connection for the migration lock: %w", err)
+	}
+
+	var release func(ctx context.Context) error
+	if driver == "mysql" {
+		err = lockMySQL(ctx, conn)
+		release = func(ctx context.Context) error {
+			_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", mysqlLockName)
+			return err
+		}
+	} else {
+		_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", postgresLockID)
+		release = func(ctx context.Context) error {
+			_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", postgresLockID)
+			return err
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+
+	return func() {
+		// The pooled connection stays open, so the lock is released
+		// explicitly, even when ctx has expired
+		_ = release(context.WithoutCancel(ctx))
+		conn.Close()
+	}, nil
+}
+
+// lockMySQL waits for the named MySQL lock for as long as ctx allows
+func lockMySQL(ctx context.Context, conn *sql.Conn) error {
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", mysqlLockName).Scan(&locked); err != nil {
+		return err
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("GET_LOCK(%q) did not succeed", mysqlLockName)
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+// Package migration applies the embedded SQL migrations. Progress is kept in
+// a golang-migrate compatible schema_migrations table, so databases migrated
+// with the migrate CLI can be taken over as they are.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// ErrDirty is returned when a previous migration failed half way. The schema
+// must be repaired by hand and the version set with Force.
+var ErrDirty = errors.New("database schema is dirty")
+
+// ErrNotMigrated is returned by CheckCurrent when no migration has ever run
+var ErrNotMigrated = errors.New("database schema is not migrated, run the migrate up command")
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes the schema version of a database
+type Status struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending []Migration
+}
+
+// Migrator applies migrations to a database
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New creates a migrator for the migrations of driver found in fsys
+func New(db *gorm.DB, fsys fs.FS, driver string) (*Migrator, error) {
+	migrations, err := Load(fsys, driver)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("no migrations found for driver %q", driver)
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Load reads the migrations in the driver directory of fsys ordered by version
+func Load(fsys fs.FS, driver string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, driver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		m := fileNamePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(driver, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: m[2]}
+			byVersion[uint(version)] = migration
+		}
+		if m[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Latest returns the version of the newest migration
+func (m *Migrator) Latest() uint {
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status reports the current version and the migrations still to apply
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	version, dirty, err := m.version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{Version: version, Dirty: dirty, Latest: m.Latest()}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// Up applies every pending migration and returns how many ran. Concurrent
+// calls from other processes wait for it on MySQL and PostgreSQL.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status.Dirty {
+		return 0, fmt.Errorf("%w at version %d", ErrDirty, status.Version)
+	}
+
+	for i, migration := range status.Pending {
+		if err := m.run(ctx, migration.Version, migration.Version, migration.Up); err != nil {
+			return i, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return len(status.Pending), nil
+}
+
+// Down rolls back the last steps migrations and returns how many ran
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	version, dirty, err := m.version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d", ErrDirty, version)
+	}
+
+	done := 0
+	for i := len(m.migrations) - 1; i >= 0 && done < steps; i-- {
+		migration := m.migrations[i]
+		if migration.Version > version {
+			continue
+		}
+
+		var previous uint
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err := m.run(ctx, migration.Version, previous, migration.Down); err != nil {
+			return done, fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done++
+	}
+	return done, nil
+}
+
+// Force sets the schema version without running any migration and clears
+// the dirty flag. Version 0 means no migration is applied.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+	return m.setVersion(ctx, version, false)
+}
+
+// CheckCurrent returns an error unless every migration has been applied. It
+// only reads, so it is safe to call from the readiness probe with an account
+// that may not change the schema.
+func (m *Migrator) CheckCurrent(ctx context.Context) error {
+	exists, err := m.hasTable(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotMigrated
+	}
+
+	version, dirty, err := m.readVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, version)
+	}
+	if version < m.Latest() {
+		return fmt.Errorf("database schema is at version %d but %d is required, run the migrate up command",
+			version, m.Latest())
+	}
+	return nil
+}
+
+// run executes script, marking the schema dirty at version while it runs
+// and recording target once it succeeds
+func (m *Migrator) run(ctx context.Context, version, target uint, script string) error {
+	if err := m.setVersion(ctx, version, true); err != nil {
+		return err
+	}
+
+	for _, statement := range splitStatements(script) {
+		if err := m.db.WithContext(ctx).Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return m.setVersion(ctx, target, false)
+}
+
+// hasTable reports whether the schema_migrations table exists. Unlike
+// gorm's HasTable it returns query errors, so an unreachable database is not
+// mistaken for an unmigrated one.
+func (m *Migrator) hasTable(ctx context.Context) (bool, error) {
+	var query string
+	switch m.db.Dialector.Name() {
+	case "mysql":
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	case "postgres":
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_name = ?"
+	default:
+		query = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	}
+
+	var count int64
+	if err := m.db.WithContext(ctx).Raw(query, "schema_migrations").Scan(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to look up schema_migrations table: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (m *Migrator) version(ctx context.Context) (uint, bool, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, false, err
+	}
+	return m.readVersion(ctx)
+}
+
+// readVersion reads the schema version from an existing schema_migrations
+// table
+func (m *Migrator) readVersion(ctx context.Context) (uint, bool, error) {
+	var rows []struct {
+		Version int64
+		Dirty   bool
+	}
+	err := m.db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations").Scan(&rows).Error
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if len(rows) == 0 || rows[0].Version < 0 {
+		return 0, false, nil
+	}
+	return uint(rows[0].Version), rows[0].Dirty, nil
+}
+
+func (m *Migrator) setVersion(ctx context.Context, version uint, dirty bool) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+			return err
+		}
+		if version == 0 && !dirty {
+			return nil
+		}
+		return tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", version, dirty).Error
+	})
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	err := m.db.WithContext(ctx).
+		Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)").
+		Error
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package migration
+
+import (
+	"regexp"
+	"strings"
+)
+
+// dollarTag matches the opening tag of a PostgreSQL dollar-quoted string,
+// such as $$ or $body$
+var dollarTag = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// splitStatements splits a migration script on the semicolons that end its
+// statements. Drivers differ in whether they accept several statements in one
+// Exec, so each statement is sent on its own. Semicolons inside quotes,
+// dollar-quoted bodies and comments are ignored and comment-only statements
+// are dropped.
+func splitStatements(script string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+		hasCode    bool
+	)
+
+	flush := func() {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(current.String()))
+		}
+		current.Reset()
+		hasCode = false
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '-' && i+1 < len(script) && script[i+1] == '-':
+			// Line comment: skip to the end of the line
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+				continue
+			}
+			i += end
+			current.WriteByte('\n')
+		case c == '/' && i+1 < len(script) && script[i+1] == '*':
+			// Block comment: skip past its end
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+				continue
+			}
+			i += end + 3
+			current.WriteByte(' ')
+		case c == '$' && dollarTag.MatchString(script[i:]):
+			// Dollar-quoted body, e.g. of a function, up to the same tag
+			tag := dollarTag.FindString(script[i:])
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				end = len(script)
+			} else {
+				end = i + len(tag) + end + len(tag)
+			}
+			current.WriteString(script[i:end])
+			hasCode = true
+			i = end - 1
+		case c == '\'' || c == '"' || c == '`':
+			// Quoted literal or identifier; a doubled quote is an escape
+			end := i + 1
+			for end < len(script) {
+				if script[end] == c {
+					if end+1 < len(script) && script[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			current.WriteString(script[i:min(end+1, len(script))])
+			hasCode = true
+			i = end
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+			if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+				hasCode = true
+			}
+		}
+	}
+	flush()
+
+	return statements
+}
@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "empty",
+			script: "  \n\t",
+			want:   nil,
+		},
+		{
+			name:   "one statement without semicolon",
+			script: "CREATE TABLE a (id INT)",
+			want:   []string{"CREATE TABLE a (id INT)"},
+		},
+		{
+			name:   "several statements",
+			script: "CREATE TABLE a (id INT);\nCREATE INDEX idx_a ON a(id);\n",
+			want:   []string{"CREATE TABLE a (id INT)", "CREATE INDEX idx_a ON a(id)"},
+		},
+		{
+			name:   "empty statements",
+			script: ";; SELECT 1;;",
+			want:   []string{"SELECT 1"},
+		},
+		{
+			name:   "semicolon in single quotes",
+			script: "INSERT INTO a VALUES ('x;y'); SELECT 2",
+			want:   []string{"INSERT INTO a VALUES ('x;y')", "SELECT 2"},
+		},
+		{
+			name:   "doubled quote escape",
+			script: "INSERT INTO a VALUES ('it''s; fine'); SELECT 2",
+			want:   []string{"INSERT INTO a VALUES ('it''s; fine')", "SELECT 2"},
+		},
+		{
+			name:   "double quoted and backquoted identifiers",
+			script: "CREATE TABLE \"a;b\" (id INT); CREATE TABLE `c;d` (id INT)",
+			want:   []string{"CREATE TABLE \"a;b\" (id INT)", "CREATE TABLE `c;d` (id INT)"},
+		},
+		{
+			name:   "unterminated quote",
+			script: "SELECT 'abc;",
+			want:   []string{"SELECT 'abc;"},
+		},
+		{
+			name:   "line comments",
+			script: "-- create a; really\nCREATE TABLE a (id INT); -- trailing; comment\n-- only a comment;",
+			want:   []string{"CREATE TABLE a (id INT)"},
+		},
+		{
+			name:   "comment markers inside quotes",
+			script: "SELECT '-- not a comment; /* nor this */'",
+			want:   []string{"SELECT '-- not a comment; /* nor this */'"},
+		},
+		{
+			name:   "block comments",
+			script: "/* header; with semicolon */ SELECT 1; /* only a comment; */; SELECT /* inline; */ 2",
+			want:   []string{"SELECT 1", "SELECT   2"},
+		},
+		{
+			name:   "unterminated block comment",
+			script: "SELECT 1; /* never closed; SELECT 2",
+			want:   []string{"SELECT 1"},
+		},
+		{
+			name: "dollar-quoted function body",
+			script: `CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER t BEFORE UPDATE ON a FOR EACH ROW EXECUTE FUNCTION touch();`,
+			want: []string{
+				"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n    NEW.updated_at = NOW();\n    RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+				"CREATE TRIGGER t BEFORE UPDATE ON a FOR EACH ROW EXECUTE FUNCTION touch()",
+			},
+		},
+		{
+			name:   "tagged dollar quote containing $$",
+			script: "DO $body$ BEGIN PERFORM '$$;'; END $body$; SELECT 1",
+			want:   []string{"DO $body$ BEGIN PERFORM '$$;'; END $body$", "SELECT 1"},
+		},
+		{
+			name:   "dollar sign that is no quote",
+			script: "SELECT price$ FROM a; SELECT 1",
+			want:   []string{"SELECT price$ FROM a", "SELECT 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitStatements(tt.script)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("splitStatements(%q)\n got %q\nwant %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
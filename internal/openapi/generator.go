@@ -0,0 +1,525 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info holds the general API information
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components holds the reusable schemas
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation describes a single API operation on a path
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes an operation's request body
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response describes a single response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a request or response body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is the subset of the OpenAPI schema object used by the generator
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+}
+
+var (
+	paramPattern    = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(true|false)(?:\s+"(.*)")?$`)
+	responsePattern = regexp.MustCompile(`^(\d+)\s+\{(\w+)\}\s+(\S+)(?:\s+"(.*)")?$`)
+	routerPattern   = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+)
+
+var mimeTypes = map[string]string{
+	"json": "application/json",
+	"html": "text/html",
+	"text": "text/plain",
+}
+
+// Generate builds an OpenAPI document from the swag-style annotations in
+// the Go packages found in dirs. General API info (@title, @version and
+// @description) is read from any annotated function, typically main; the
+// schemas of the referenced types are derived from their struct definitions.
+func Generate(dirs ...string) (*Document, error) {
+	g := &generator{
+		types: make(map[string]*ast.TypeSpec),
+		doc: &Document{
+			OpenAPI:    "3.0.3",
+			Paths:      make(map[string]map[string]Operation),
+			Components: Components{Schemas: make(map[string]*Schema)},
+		},
+	}
+
+	var funcs []*ast.FuncDecl
+	for _, dir := range dirs {
+		pkgFuncs, err := g.parseDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		funcs = append(funcs, pkgFuncs...)
+	}
+
+	for _, fn := range funcs {
+		if err := g.addOperation(fn); err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.Name.Name, err)
+		}
+	}
+
+	return g.doc, nil
+}
+
+type generator struct {
+	// types maps "pkg.Name" to its declaration
+	types map[string]*ast.TypeSpec
+	doc   *Document
+}
+
+func (g *generator) parseDir(dir string) ([]*ast.FuncDecl, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []*ast.FuncDecl
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg := file.Name.Name
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						g.types[pkg+"."+ts.Name.Name] = ts
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Doc != nil {
+					funcs = append(funcs, d)
+				}
+			}
+		}
+	}
+
+	// Keep the output stable regardless of file order
+	sort.Slice(funcs, func(i, j int) bool { return funcs[i].Name.Name < funcs[j].Name.Name })
+
+	return funcs, nil
+}
+
+func (g *generator) addOperation(fn *ast.FuncDecl) error {
+	op := Operation{
+		OperationID: fn.Name.Name,
+		Responses:   make(map[string]Response),
+	}
+
+	var path, method string
+	accept := []string{"application/json"}
+	produce := []string{"application/json"}
+
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "@title":
+			g.doc.Info.Title = value
+		case "@version":
+			g.doc.Info.Version = value
+		case "@description":
+			g.doc.Info.Description = value
+		case "@Summary":
+			op.Summary = value
+		case "@Description":
+			op.Description = value
+		case "@Tags":
+			op.Tags = strings.Split(value, ",")
+		case "@Accept":
+			accept = parseMimeTypes(value)
+		case "@Produce":
+			produce = parseMimeTypes(value)
+		case "@Param":
+			if err := g.addParam(&op, value, accept); err != nil {
+				return err
+			}
+		case "@Success", "@Failure":
+			if err := g.addResponse(&op, value, produce); err != nil {
+				return err
+			}
+		case "@Router":
+			m := routerPattern.FindStringSubmatch(value)
+			if m == nil {
+				return fmt.Errorf("invalid @Router annotation: %q", value)
+			}
+			path, method = m[1], strings.ToLower(m[2])
+		}
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	if g.doc.Paths[path] == nil {
+		g.doc.Paths[path] = make(map[string]Operation)
+	}
+	if _, exists := g.doc.Paths[path][method]; exists {
+		return fmt.Errorf("duplicate route %s %s", method, path)
+	}
+	g.doc.Paths[path][method] = op
+
+	return nil
+}
+
+func (g *generator) addParam(op *Operation, value string, accept []string) error {
+	m := paramPattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Errorf("invalid @Param annotation: %q", value)
+	}
+	name, in, typ, required, description := m[1], m[2], m[3], m[4] == "true", m[5]
+
+	schema, err := g.schemaFor(typ)
+	if err != nil {
+		return err
+	}
+
+	if in == "body" {
+		content := make(map[string]MediaType, len(accept))
+		for _, mime := range accept {
+			content[mime] = MediaType{Schema: schema}
+		}
+		op.RequestBody = &RequestBody{
+			Description: description,
+			Required:    required,
+			Content:     content,
+		}
+		return nil
+	}
+
+	op.Parameters = append(op.Parameters, Parameter{
+		Name:        name,
+		In:          in,
+		Description: description,
+		Required:    required || in == "path",
+		Schema:      schema,
+	})
+	return nil
+}
+
+func (g *generator) addResponse(op *Operation, value string, produce []string) error {
+	m := responsePattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Errorf("invalid response annotation: %q", value)
+	}
+	code, kind, typ, description := m[1], m[2], m[3], m[4]
+
+	schema, err := g.schemaFor(typ)
+	if err != nil {
+		return err
+	}
+	if kind == "array" {
+		schema = &Schema{Type: "array", Items: schema}
+	}
+
+	if description == "" {
+		description = defaultDescription(code)
+	}
+
+	content := make(map[string]MediaType, len(produce))
+	for _, mime := range produce {
+		content[mime] = MediaType{Schema: schema}
+	}
+	op.Responses[code] = Response{Description: description, Content: content}
+	return nil
+}
+
+// schemaFor resolves an annotation type such as "int", "string",
+// "object" or "domain.CreateMenuRequest" into a schema
+func (g *generator) schemaFor(typ string) (*Schema, error) {
+	switch typ {
+	case "int", "integer":
+		return &Schema{Type: "integer"}, nil
+	case "number":
+		return &Schema{Type: "number"}, nil
+	case "string":
+		return &Schema{Type: "string"}, nil
+	case "bool", "boolean":
+		return &Schema{Type: "boolean"}, nil
+	case "object":
+		return &Schema{Type: "object"}, nil
+	}
+
+	pkg, name, ok := strings.Cut(typ, ".")
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	return g.namedSchema(pkg, name)
+}
+
+// namedSchema returns a reference to a struct type, registering its
+// component schema on first use, or the inline schema of other named types
+func (g *generator) namedSchema(pkg, name string) (*Schema, error) {
+	key := pkg + "." + name
+	ts, ok := g.types[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", key)
+	}
+
+	st, isStruct := ts.Type.(*ast.StructType)
+	if !isStruct {
+		return g.exprSchema(pkg, ts.Type)
+	}
+
+	ref := &Schema{Ref: "#/components/schemas/" + key}
+	if _, done := g.doc.Components.Schemas[key]; done {
+		return ref, nil
+	}
+
+	// Register a placeholder first so self-referencing types terminate
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.doc.Components.Schemas[key] = schema
+	if err := g.addFields(schema, pkg, st); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+func (g *generator) addFields(schema *Schema, pkg string, st *ast.StructType) error {
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			unquoted, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return err
+			}
+			tag = reflect.StructTag(unquoted)
+		}
+
+		jsonName, _, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		// Embedded structs are flattened like encoding/json does
+		if len(field.Names) == 0 {
+			ident, ok := field.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			embedded, ok := g.types[pkg+"."+ident.Name]
+			if !ok {
+				return fmt.Errorf("unknown embedded type %s.%s", pkg, ident.Name)
+			}
+			if est, ok := embedded.Type.(*ast.StructType); ok {
+				if err := g.addFields(schema, pkg, est); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+
+			name := jsonName
+			if name == "" {
+				name = fieldName.Name
+			}
+
+			fieldSchema, err := g.exprSchema(pkg, field.Type)
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", pkg, fieldName.Name, err)
+			}
+			schema.Properties[name] = fieldSchema
+
+			// Rules come from gin binding tags or service validate tags
+			rules := strings.Split(tag.Get("binding")+","+tag.Get("validate"), ",")
+			if slices.Contains(rules, "required") {
+				schema.Required = append(schema.Required, name)
+			}
+			applyRules(fieldSchema, rules)
+		}
+	}
+	return nil
+}
+
+// applyRules maps the length and range rules of a validator tag onto schema
+func applyRules(schema *Schema, rules []string) {
+	for _, rule := range rules {
+		name, param, ok := strings.Cut(rule, "=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			continue
+		}
+		switch {
+		case name == "max" && schema.Type == "string":
+			schema.MaxLength = &n
+		case (name == "min" || name == "gte") && schema.Type == "integer":
+			schema.Minimum = &n
+		}
+	}
+}
+
+func (g *generator) exprSchema(pkg string, expr ast.Expr) (*Schema, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return &Schema{Type: "string"}, nil
+		case "bool":
+			return &Schema{Type: "boolean"}, nil
+		case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+			return &Schema{Type: "integer", Format: "int32"}, nil
+		case "int64", "uint64":
+			return &Schema{Type: "integer", Format: "int64"}, nil
+		case "float32", "float64":
+			return &Schema{Type: "number"}, nil
+		case "any":
+			return &Schema{}, nil
+		}
+		return g.namedSchema(pkg, t.Name)
+	case *ast.StarExpr:
+		schema, err := g.exprSchema(pkg, t.X)
+		if err != nil {
+			return nil, err
+		}
+		if schema.Ref != "" {
+			// $ref siblings are ignored, so nullable refs stay as they are
+			return schema, nil
+		}
+		nullable := *schema
+		nullable.Nullable = true
+		return &nullable, nil
+	case *ast.ArrayType:
+		items, err := g.exprSchema(pkg, t.Elt)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case *ast.MapType:
+		values, err := g.exprSchema(pkg, t.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case *ast.InterfaceType:
+		return &Schema{}, nil
+	case *ast.SelectorExpr:
+		ident, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported selector type")
+		}
+		if ident.Name == "time" && t.Sel.Name == "Time" {
+			return &Schema{Type: "string", Format: "date-time"}, nil
+		}
+		if ident.Name == "time" && t.Sel.Name == "Duration" {
+			return &Schema{Type: "integer", Format: "int64"}, nil
+		}
+		return g.namedSchema(ident.Name, t.Sel.Name)
+	}
+
+	return nil, fmt.Errorf("unsupported type %T", expr)
+}
+
+func parseMimeTypes(value string) []string {
+	var types []string
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if mime, ok := mimeTypes[v]; ok {
+			v = mime
+		}
+		types = append(types, v)
+	}
+	return types
+}
+
+func defaultDescription(code string) string {
+	status, err := strconv.Atoi(code)
+	if err != nil {
+		return code
+	}
+	if text := http.StatusText(status); text != "" {
+		return text
+	}
+	return code
+}
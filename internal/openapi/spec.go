@@ -0,0 +1,19 @@
+package openapi
+
+import _ "embed"
+
+//go:generate go run ../../cmd/openapi -out openapi.json
+
+// SourceDirs are the packages, relative to the module root, whose
+// annotations and types make up the specification
+var SourceDirs = []string{
+	"cmd/api",
+	"internal/handler",
+	"internal/domain",
+	"pkg/response",
+}
+
+// Spec is the generated OpenAPI document served at /openapi.json
+//
+//go:embed openapi.json
+var Spec []byte
@@ -0,0 +1,110 @@
+// Package ratelimit implements in-memory token buckets, one per client key
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter allows Limit requests per window for every key. Each key owns a
+// token bucket holding up to Limit tokens that refills at Limit per window,
+// so clients can burst up to the limit and then continue at the average rate.
+type Limiter struct {
+	limit  int
+	window time.Duration
+	// rate is the refill speed in tokens per second
+	rate float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Result describes the state of a bucket after a request was counted
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed; zero when
+	// the request was allowed
+	RetryAfter time.Duration
+}
+
+// New creates a limiter allowing limit requests per window and key
+func New(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		window:  window,
+		rate:    float64(limit) / window.Seconds(),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Limit returns the number of requests allowed per window
+func (l *Limiter) Limit() int {
+	return l.limit
+}
+
+// Window returns the period over which Limit requests are allowed
+func (l *Limiter) Window() time.Duration {
+	return l.window
+}
+
+// Allow takes a token from the bucket of key if one is available
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.limit), b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	result := Result{Limit: l.limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.duration(1 - b.tokens)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = l.duration(float64(l.limit) - b.tokens)
+	return result
+}
+
+// duration returns the time needed to refill the given number of tokens
+func (l *Limiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep drops buckets that have been idle long enough to be full again, so
+// memory is bounded by the clients seen within one window. It runs at most
+// once per window.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.window {
+			delete(l.buckets, key)
+		}
+	}
+}
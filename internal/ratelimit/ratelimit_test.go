@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// clock is a fake time source that only moves when advanced
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestLimiter(limit int, window time.Duration) (*Limiter, *clock) {
+	c := &clock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(limit, window)
+	l.now = c.now
+	return l, c
+}
+
+func TestAllowBurstsUpToLimit(t *testing.T) {
+	l, _ := newTestLimiter(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		r := l.Allow("a")
+		if !r.Allowed || r.Remaining != 2-i || r.RetryAfter != 0 {
+			t.Fatalf("request %d: %+v, want allowed with %d remaining", i+1, r, 2-i)
+		}
+	}
+
+	r := l.Allow("a")
+	if r.Allowed || r.Remaining != 0 {
+		t.Fatalf("request over limit: %+v, want denied", r)
+	}
+	// One token refills every 20s; the bucket is full again after 60s
+	if r.RetryAfter != 20*time.Second || r.Reset != time.Minute {
+		t.Errorf("retry after %s, reset %s, want 20s and 1m", r.RetryAfter, r.Reset)
+	}
+}
+
+func TestAllowRefillsOverTime(t *testing.T) {
+	l, c := newTestLimiter(3, time.Minute)
+	for i := 0; i < 3; i++ {
+		l.Allow("a")
+	}
+
+	c.advance(10 * time.Second)
+	if r := l.Allow("a"); r.Allowed || r.RetryAfter != 10*time.Second {
+		t.Errorf("after 10s: %+v, want denied for another 10s", r)
+	}
+
+	c.advance(10 * time.Second)
+	if r := l.Allow("a"); !r.Allowed || r.Remaining != 0 {
+		t.Errorf("after 20s: %+v, want one request allowed", r)
+	}
+
+	// Refill stops at the limit
+	c.advance(time.Hour)
+	if r := l.Allow("a"); !r.Allowed || r.Remaining != 2 {
+		t.Errorf("after an hour: %+v, want a full bucket", r)
+	}
+}
+
+func TestAllowKeepsKeysApart(t *testing.T) {
+	l, _ := newTestLimiter(1, time.Minute)
+
+	if !l.Allow("a").Allowed {
+		t.Fatal("first request of a denied")
+	}
+	if l.Allow("a").Allowed {
+		t.Error("second request of a allowed")
+	}
+	if !l.Allow("b").Allowed {
+		t.Error("b denied by the budget of a")
+	}
+}
+
+func TestSweepDropsIdleBuckets(t *testing.T) {
+	l, c := newTestLimiter(2, time.Minute)
+	l.Allow("idle")
+	c.advance(30 * time.Second)
+	l.Allow("active")
+
+	// The next sweep runs a window after the first one
+	c.advance(30 * time.Second)
+	l.Allow("active")
+
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("idle bucket kept after a window")
+	}
+	if _, ok := l.buckets["active"]; !ok {
+		t.Error("active bucket dropped")
+	}
+
+	// A dropped client starts again with a full bucket
+	if r := l.Allow("idle"); !r.Allowed || r.Remaining != 1 {
+		t.Errorf("returning client: %+v, want a full bucket", r)
+	}
+}
+
+func TestSweepRunsOncePerWindow(t *testing.T) {
+	l, c := newTestLimiter(2, time.Minute)
+	l.Allow("a")
+	c.advance(30 * time.Second)
+	l.Allow("b")
+	c.advance(30 * time.Second)
+	l.Allow("c")
+
+	// b has been idle for a window, but the last sweep was 31s ago
+	c.advance(31 * time.Second)
+	l.Allow("c")
+	if _, ok := l.buckets["b"]; !ok {
+		t.Fatal("bucket dropped before the next sweep")
+	}
+
+	c.advance(29 * time.Second)
+	l.Allow("c")
+	if _, ok := l.buckets["b"]; ok {
+		t.Error("idle bucket kept after the next sweep")
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"errors"
+
+	"stk-technical-test-api/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// translateError converts GORM errors into domain errors. notFound is the
+// message used when no record matched.
+func translateError(err error, notFound string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domain.WrapError(domain.ErrNotFound, notFound, err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return domain.WrapError(domain.ErrConflict, "a record with the same unique value already exists", err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return domain.WrapError(domain.ErrConflict, "the record references or is referenced by another record", err)
+	}
+	return err
+}
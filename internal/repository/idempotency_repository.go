@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository instance
+func NewIdempotencyRepository(db *gorm.DB) domain.IdempotencyRepository {
+	return &idempotencyRepository{
+		db: db,
+	}
+}
+
+func (r *idempotencyRepository) Reserve(ctx context.Context, rec *domain.IdempotencyRecord, abandonedBefore time.Time) (*domain.IdempotencyRecord, error) {
+	db := r.db.WithContext(ctx)
+
+	// Free the key when its record expired or its request never finished
+	err := db.Where("scope = ? AND idempotency_key = ?", rec.Scope, rec.Key).
+		Where("expires_at <= ? OR (status_code = 0 AND created_at < ?)", rec.CreatedAt, abandonedBefore).
+		Delete(&domain.IdempotencyRecord{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// A taken key is the expected outcome of a retry, so it is not an error
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(rec)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return nil, result.Error
+	}
+
+	// Another request holds the key
+	var existing domain.IdempotencyRecord
+	err = db.Where("scope = ? AND idempotency_key = ?", rec.Scope, rec.Key).First(&existing).Error
+	if err != nil {
+		return nil, translateError(err, "idempotency key not found")
+	}
+	return &existing, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	return r.db.WithContext(ctx).Model(rec).Select("status_code", "content_type", "response_body").Updates(rec).Error
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&domain.IdempotencyRecord{}, id).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
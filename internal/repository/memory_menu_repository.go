@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// memoryMenuRepository keeps menus in memory. It behaves like the GORM
+// repository, including its errors and ordering, so it can stand in for it
+// in tests and local tooling.
+type memoryMenuRepository struct {
+	// mu guards the state; a transaction holds it until it commits
+	mu    *sync.Mutex
+	state *memoryState
+}
+
+// memoryState is the data of an in-memory repository
+type memoryState struct {
+	menus   map[int64]domain.Menu
+	events  []domain.OutboxEvent
+	nextID  int64
+	eventID int64
+}
+
+// NewMemoryMenuRepository creates an empty in-memory menu repository
+func NewMemoryMenuRepository() domain.MenuRepository {
+	return &memoryMenuRepository{
+		mu:    &sync.Mutex{},
+		state: &memoryState{menus: make(map[int64]domain.Menu)},
+	}
+}
+
+func (r *memoryMenuRepository) Create(ctx context.Context, menu *domain.Menu) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu.UUID = uuid.New().String()
+	if err := r.setLevel(menu); err != nil {
+		return err
+	}
+	if err := r.checkUnique(menu); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if menu.CreatedAt.IsZero() {
+		menu.CreatedAt = now
+	}
+	if menu.UpdatedAt.IsZero() {
+		menu.UpdatedAt = now
+	}
+
+	r.state.nextID++
+	menu.ID = r.state.nextID
+	r.state.menus[menu.ID] = cloneMenu(*menu)
+	return nil
+}
+
+func (r *memoryMenuRepository) Update(ctx context.Context, menu *domain.Menu) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.setLevel(menu); err != nil {
+		return err
+	}
+	if err := r.checkUnique(menu); err != nil {
+		return err
+	}
+
+	menu.UpdatedAt = time.Now()
+	r.state.menus[menu.ID] = cloneMenu(*menu)
+	r.setDescendantLevels(menu.ID, menu.Level)
+	return nil
+}
+
+func (r *memoryMenuRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range r.state.menus {
+		if m.ParentID != nil && *m.ParentID == id {
+			return domain.NewError(domain.ErrHasChildren, "cannot delete menu with children")
+		}
+	}
+	delete(r.state.menus, id)
+	return nil
+}
+
+func (r *memoryMenuRepository) FindByID(ctx context.Context, id int64) (*domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.find(id)
+}
+
+// FindByIDForUpdate needs no row lock; transactions hold the whole
+// repository
+func (r *memoryMenuRepository) FindByIDForUpdate(ctx context.Context, id int64) (*domain.Menu, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *memoryMenuRepository) FindByUUID(ctx context.Context, uuid string) (*domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range r.state.menus {
+		if m.UUID == uuid {
+			menu := cloneMenu(m)
+			return &menu, nil
+		}
+	}
+	return nil, errMenuNotFound()
+}
+
+func (r *memoryMenuRepository) FindByCodes(ctx context.Context, codes []string) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.filter(func(m domain.Menu) bool {
+		return slices.Contains(codes, m.Code)
+	}), nil
+}
+
+func (r *memoryMenuRepository) FindByIDs(ctx context.Context, ids []int64) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.filter(func(m domain.Menu) bool {
+		return slices.Contains(ids, m.ID)
+	}), nil
+}
+
+func (r *memoryMenuRepository) FindAll(ctx context.Context) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.filter(func(domain.Menu) bool { return true }), nil
+}
+
+func (r *memoryMenuRepository) FindByParentID(ctx context.Context, parentID *int64) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.children(parentID), nil
+}
+
+func (r *memoryMenuRepository) FindRootMenus(ctx context.Context) ([]domain.Menu, error) {
+	return r.FindByParentID(ctx, nil)
+}
+
+func (r *memoryMenuRepository) FindHierarchical(ctx context.Context) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roots := r.children(nil)
+	for i := range roots {
+		r.loadChildren(&roots[i])
+	}
+	return roots, nil
+}
+
+func (r *memoryMenuRepository) FindHierarchicalByRootID(ctx context.Context, rootID int64) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	root, err := r.find(rootID)
+	if err != nil {
+		return nil, err
+	}
+	r.loadChildren(root)
+	return []domain.Menu{*root}, nil
+}
+
+func (r *memoryMenuRepository) FindDetailByID(ctx context.Context, id int64) (*domain.MenuDetail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu, err := r.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.MenuDetail{
+		Menu:  *menu,
+		Depth: menu.Level,
+	}
+	if menu.ParentID != nil {
+		if parent, ok := r.state.menus[*menu.ParentID]; ok {
+			detail.ParentData = &domain.MenuParentInfo{
+				ID:   parent.ID,
+				UUID: parent.UUID,
+				Name: parent.Name,
+				Code: parent.Code,
+			}
+		}
+	}
+	return detail, nil
+}
+
+func (r *memoryMenuRepository) FindChildrenByParentID(ctx context.Context, parentID int64) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.children(&parentID), nil
+}
+
+func (r *memoryMenuRepository) FindChildrenByParentIDs(ctx context.Context, parentIDs []int64) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.filter(func(m domain.Menu) bool {
+		return m.ParentID != nil && slices.Contains(parentIDs, *m.ParentID)
+	}), nil
+}
+
+func (r *memoryMenuRepository) Search(ctx context.Context, query string, limit int) ([]domain.Menu, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query = strings.ToLower(query)
+	contains := func(s *string) bool {
+		return s != nil && strings.Contains(strings.ToLower(*s), query)
+	}
+	menus := r.filter(func(m domain.Menu) bool {
+		return contains(&m.Name) || contains(&m.Code) || contains(m.Route)
+	})
+
+	slices.SortStableFunc(menus, func(a, b domain.Menu) int {
+		return cmp.Compare(a.Level, b.Level)
+	})
+	if limit >= 0 && len(menus) > limit {
+		menus = menus[:limit]
+	}
+	return menus, nil
+}
+
+func (r *memoryMenuRepository) CountByActive(ctx context.Context) (*domain.MenuCounts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := &domain.MenuCounts{}
+	for _, m := range r.state.menus {
+		if m.IsActive {
+			counts.Active++
+		} else {
+			counts.Inactive++
+		}
+	}
+	return counts, nil
+}
+
+func (r *memoryMenuRepository) EnqueueEvent(ctx context.Context, event *domain.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state.eventID++
+	event.ID = r.state.eventID
+	stored := *event
+	stored.AffectedIDs = slices.Clone(event.AffectedIDs)
+	r.state.events = append(r.state.events, stored)
+	return nil
+}
+
+// WithTx runs fn on a copy of the data and keeps the copy if fn succeeds.
+// The repository is locked meanwhile, so transactions are serializable and
+// fn must only use the repository it is given.
+func (r *memoryMenuRepository) WithTx(ctx context.Context, fn func(repo domain.MenuRepository) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &memoryMenuRepository{mu: &sync.Mutex{}, state: r.state.clone()}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	r.state = tx.state
+	return nil
+}
+
+// find returns a copy of the menu with the given ID
+func (r *memoryMenuRepository) find(id int64) (*domain.Menu, error) {
+	m, ok := r.state.menus[id]
+	if !ok {
+		return nil, errMenuNotFound()
+	}
+	menu := cloneMenu(m)
+	return &menu, nil
+}
+
+// filter returns copies of the matching menus in order_index, id order
+func (r *memoryMenuRepository) filter(match func(domain.Menu) bool) []domain.Menu {
+	var menus []domain.Menu
+	for _, m := range r.state.menus {
+		if match(m) {
+			menus = append(menus, cloneMenu(m))
+		}
+	}
+	slices.SortFunc(menus, func(a, b domain.Menu) int {
+		return cmp.Or(cmp.Compare(a.OrderIndex, b.OrderIndex), cmp.Compare(a.ID, b.ID))
+	})
+	return menus
+}
+
+// children returns the menus under parentID, or the roots when it is nil
+func (r *memoryMenuRepository) children(parentID *int64) []domain.Menu {
+	return r.filter(func(m domain.Menu) bool {
+		if parentID == nil {
+			return m.ParentID == nil
+		}
+		return m.ParentID != nil && *m.ParentID == *parentID
+	})
+}
+
+func (r *memoryMenuRepository) loadChildren(menu *domain.Menu) {
+	children := r.children(&menu.ID)
+	if len(children) == 0 {
+		return
+	}
+	for i := range children {
+		r.loadChildren(&children[i])
+	}
+	menu.Children = children
+}
+
+// setLevel places the menu one level below its parent
+func (r *memoryMenuRepository) setLevel(menu *domain.Menu) error {
+	if menu.ParentID == nil {
+		menu.Level = 0
+		return nil
+	}
+	parent, ok := r.state.menus[*menu.ParentID]
+	if !ok {
+		return parentError(errMenuNotFound())
+	}
+	menu.Level = parent.Level + 1
+	return nil
+}
+
+// setDescendantLevels places the descendants of a moved menu below it
+func (r *memoryMenuRepository) setDescendantLevels(id int64, level int) {
+	for childID, m := range r.state.menus {
+		if m.ParentID != nil && *m.ParentID == id {
+			m.Level = level + 1
+			r.state.menus[childID] = m
+			r.setDescendantLevels(childID, m.Level)
+		}
+	}
+}
+
+// checkUnique enforces the unique indexes on code and uuid
+func (r *memoryMenuRepository) checkUnique(menu *domain.Menu) error {
+	for _, m := range r.state.menus {
+		if m.ID != menu.ID && (m.Code == menu.Code || m.UUID == menu.UUID) {
+			return domain.NewError(domain.ErrConflict, "menu code already exists")
+		}
+	}
+	return nil
+}
+
+func (s *memoryState) clone() *memoryState {
+	c := *s
+	c.menus = make(map[int64]domain.Menu, len(s.menus))
+	for id, m := range s.menus {
+		c.menus[id] = m
+	}
+	c.events = slices.Clone(s.events)
+	return &c
+}
+
+func errMenuNotFound() error {
+	return domain.NewError(domain.ErrNotFound, "menu not found")
+}
+
+// cloneMenu copies a menu so callers cannot change the stored one through
+// its pointer fields. Children are not stored and are left out.
+func cloneMenu(m domain.Menu) domain.Menu {
+	m.ParentID = clonePtr(m.ParentID)
+	m.Description = clonePtr(m.Description)
+	m.Route = clonePtr(m.Route)
+	m.Icon = clonePtr(m.Icon)
+	m.CreatedBy = clonePtr(m.CreatedBy)
+	m.UpdatedBy = clonePtr(m.UpdatedBy)
+	m.Children = nil
+	return m
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
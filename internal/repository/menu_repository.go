@@ -1,12 +1,15 @@
 package repository
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"strings"
 
 	"stk-technical-test-api/internal/domain"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type menuRepository struct {
@@ -20,77 +23,141 @@ func NewMenuRepository(db *gorm.DB) domain.MenuRepository {
 	}
 }
 
-func (r *menuRepository) Create(menu *domain.Menu) error {
+func (r *menuRepository) Create(ctx context.Context, menu *domain.Menu) error {
 	// Generate UUID
 	menu.UUID = uuid.New().String()
 
 	// Calculate level based on parent
 	if menu.ParentID != nil {
-		parent, err := r.FindByID(*menu.ParentID)
+		parent, err := r.FindByID(ctx, *menu.ParentID)
 		if err != nil {
-			return fmt.Errorf("parent menu not found: %w", err)
+			return parentError(err)
 		}
 		menu.Level = parent.Level + 1
 	} else {
 		menu.Level = 0
 	}
 
-	return r.db.Create(menu).Error
+	return translateMenuError(r.db.WithContext(ctx).Create(menu).Error)
 }
 
-func (r *menuRepository) Update(menu *domain.Menu) error {
+func (r *menuRepository) Update(ctx context.Context, menu *domain.Menu) error {
 	// Recalculate level if parent changed
 	if menu.ParentID != nil {
-		parent, err := r.FindByID(*menu.ParentID)
+		parent, err := r.FindByID(ctx, *menu.ParentID)
 		if err != nil {
-			return fmt.Errorf("parent menu not found: %w", err)
+			return parentError(err)
 		}
 		menu.Level = parent.Level + 1
 	} else {
 		menu.Level = 0
 	}
 
-	return r.db.Save(menu).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(menu).Error; err != nil {
+			return translateMenuError(err)
+		}
+		return updateDescendantLevels(tx, menu.ID, menu.Level)
+	})
+}
+
+// updateDescendantLevels places the descendants of a moved menu below it,
+// one tree level per query
+func updateDescendantLevels(tx *gorm.DB, id int64, level int) error {
+	ids := []int64{id}
+	for len(ids) > 0 {
+		var children []int64
+		if err := tx.Model(&domain.Menu{}).Where("parent_id IN ?", ids).Pluck("id", &children).Error; err != nil {
+			return err
+		}
+		if len(children) == 0 {
+			return nil
+		}
+
+		level++
+		err := tx.Model(&domain.Menu{}).Where("id IN ? AND level <> ?", children, level).
+			UpdateColumn("level", level).Error
+		if err != nil {
+			return err
+		}
+		ids = children
+	}
+	return nil
 }
 
-func (r *menuRepository) Delete(id int64) error {
+func (r *menuRepository) Delete(ctx context.Context, id int64) error {
 	// Check if menu has children
 	var count int64
-	r.db.Model(&domain.Menu{}).Where("parent_id = ?", id).Count(&count)
+	if err := r.db.WithContext(ctx).Model(&domain.Menu{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
 	if count > 0 {
-		return fmt.Errorf("cannot delete menu with children")
+		return domain.NewError(domain.ErrHasChildren, "cannot delete menu with children")
 	}
 
-	return r.db.Delete(&domain.Menu{}, id).Error
+	return translateMenuError(r.db.WithContext(ctx).Delete(&domain.Menu{}, id).Error)
 }
 
-func (r *menuRepository) FindByID(id int64) (*domain.Menu, error) {
+func (r *menuRepository) FindByID(ctx context.Context, id int64) (*domain.Menu, error) {
 	var menu domain.Menu
-	err := r.db.First(&menu, id).Error
+	err := r.db.WithContext(ctx).First(&menu, id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateMenuError(err)
 	}
 	return &menu, nil
 }
 
-func (r *menuRepository) FindByUUID(uuid string) (*domain.Menu, error) {
+func (r *menuRepository) FindByIDForUpdate(ctx context.Context, id int64) (*domain.Menu, error) {
 	var menu domain.Menu
-	err := r.db.Where("uuid = ?", uuid).First(&menu).Error
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&menu, id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateMenuError(err)
 	}
 	return &menu, nil
 }
 
-func (r *menuRepository) FindAll() ([]domain.Menu, error) {
+func (r *menuRepository) FindByUUID(ctx context.Context, uuid string) (*domain.Menu, error) {
+	var menu domain.Menu
+	err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&menu).Error
+	if err != nil {
+		return nil, translateMenuError(err)
+	}
+	return &menu, nil
+}
+
+func (r *menuRepository) FindByCodes(ctx context.Context, codes []string) ([]domain.Menu, error) {
+	var menus []domain.Menu
+	if len(codes) == 0 {
+		return menus, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("code IN ?", codes).
+		Order("order_index ASC, id ASC").
+		Find(&menus).Error
+	return menus, err
+}
+
+func (r *menuRepository) FindByIDs(ctx context.Context, ids []int64) ([]domain.Menu, error) {
 	var menus []domain.Menu
-	err := r.db.Order("order_index ASC, id ASC").Find(&menus).Error
+	if len(ids) == 0 {
+		return menus, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).
+		Order("order_index ASC, id ASC").
+		Find(&menus).Error
+	return menus, err
+}
+
+func (r *menuRepository) FindAll(ctx context.Context) ([]domain.Menu, error) {
+	var menus []domain.Menu
+	err := r.db.WithContext(ctx).Order("order_index ASC, id ASC").Find(&menus).Error
 	return menus, err
 }
 
-func (r *menuRepository) FindByParentID(parentID *int64) ([]domain.Menu, error) {
+func (r *menuRepository) FindByParentID(ctx context.Context, parentID *int64) ([]domain.Menu, error) {
 	var menus []domain.Menu
-	query := r.db.Order("order_index ASC, id ASC")
+	query := r.db.WithContext(ctx).Order("order_index ASC, id ASC")
 
 	if parentID == nil {
 		query = query.Where("parent_id IS NULL")
@@ -102,19 +169,19 @@ func (r *menuRepository) FindByParentID(parentID *int64) ([]domain.Menu, error)
 	return menus, err
 }
 
-func (r *menuRepository) FindRootMenus() ([]domain.Menu, error) {
+func (r *menuRepository) FindRootMenus(ctx context.Context) ([]domain.Menu, error) {
 	var menus []domain.Menu
-	err := r.db.Where("parent_id IS NULL").
+	err := r.db.WithContext(ctx).Where("parent_id IS NULL").
 		Order("order_index ASC, id ASC").
 		Find(&menus).Error
 	return menus, err
 }
 
-func (r *menuRepository) FindHierarchical() ([]domain.Menu, error) {
+func (r *menuRepository) FindHierarchical(ctx context.Context) ([]domain.Menu, error) {
 	var rootMenus []domain.Menu
 
 	// Get all root menus (parent_id IS NULL)
-	err := r.db.Where("parent_id IS NULL").
+	err := r.db.WithContext(ctx).Where("parent_id IS NULL").
 		Order("order_index ASC, id ASC").
 		Find(&rootMenus).Error
 
@@ -124,46 +191,56 @@ func (r *menuRepository) FindHierarchical() ([]domain.Menu, error) {
 
 	// Load children recursively
 	for i := range rootMenus {
-		r.loadChildren(&rootMenus[i])
+		if err := r.loadChildren(ctx, &rootMenus[i]); err != nil {
+			return nil, err
+		}
 	}
 
 	return rootMenus, nil
 }
 
-func (r *menuRepository) loadChildren(menu *domain.Menu) {
+func (r *menuRepository) loadChildren(ctx context.Context, menu *domain.Menu) error {
 	var children []domain.Menu
-	r.db.Where("parent_id = ?", menu.ID).
+	err := r.db.WithContext(ctx).Where("parent_id = ?", menu.ID).
 		Order("order_index ASC, id ASC").
-		Find(&children)
+		Find(&children).Error
+	if err != nil {
+		return err
+	}
 
 	if len(children) > 0 {
 		for i := range children {
-			r.loadChildren(&children[i])
+			if err := r.loadChildren(ctx, &children[i]); err != nil {
+				return err
+			}
 		}
 		menu.Children = children
 	}
+	return nil
 }
 
-func (r *menuRepository) FindHierarchicalByRootID(rootID int64) ([]domain.Menu, error) {
+func (r *menuRepository) FindHierarchicalByRootID(ctx context.Context, rootID int64) ([]domain.Menu, error) {
 	var rootMenu domain.Menu
 
 	// Get the specific root menu
-	err := r.db.First(&rootMenu, rootID).Error
+	err := r.db.WithContext(ctx).First(&rootMenu, rootID).Error
 	if err != nil {
-		return nil, err
+		return nil, translateMenuError(err)
 	}
 
 	// Load children recursively
-	r.loadChildren(&rootMenu)
+	if err := r.loadChildren(ctx, &rootMenu); err != nil {
+		return nil, err
+	}
 
 	return []domain.Menu{rootMenu}, nil
 }
 
-func (r *menuRepository) FindDetailByID(id int64) (*domain.MenuDetail, error) {
+func (r *menuRepository) FindDetailByID(ctx context.Context, id int64) (*domain.MenuDetail, error) {
 	var menu domain.Menu
-	err := r.db.First(&menu, id).Error
+	err := r.db.WithContext(ctx).First(&menu, id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateMenuError(err)
 	}
 
 	detail := &domain.MenuDetail{
@@ -174,7 +251,7 @@ func (r *menuRepository) FindDetailByID(id int64) (*domain.MenuDetail, error) {
 	// Get parent data if exists
 	if menu.ParentID != nil {
 		var parent domain.Menu
-		err := r.db.First(&parent, *menu.ParentID).Error
+		err := r.db.WithContext(ctx).First(&parent, *menu.ParentID).Error
 		if err == nil {
 			detail.ParentData = &domain.MenuParentInfo{
 				ID:   parent.ID,
@@ -188,11 +265,92 @@ func (r *menuRepository) FindDetailByID(id int64) (*domain.MenuDetail, error) {
 	return detail, nil
 }
 
-func (r *menuRepository) FindChildrenByParentID(parentID int64) ([]domain.Menu, error) {
+func (r *menuRepository) FindChildrenByParentID(ctx context.Context, parentID int64) ([]domain.Menu, error) {
 	var menus []domain.Menu
-	err := r.db.Where("parent_id = ?", parentID).
+	err := r.db.WithContext(ctx).Where("parent_id = ?", parentID).
 		Order("order_index ASC, id ASC").
 		Find(&menus).Error
 	return menus, err
 }
 
+func (r *menuRepository) FindChildrenByParentIDs(ctx context.Context, parentIDs []int64) ([]domain.Menu, error) {
+	var menus []domain.Menu
+	if len(parentIDs) == 0 {
+		return menus, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("parent_id IN ?", parentIDs).
+		Order("order_index ASC, id ASC").
+		Find(&menus).Error
+	return menus, err
+}
+
+func (r *menuRepository) Search(ctx context.Context, query string, limit int) ([]domain.Menu, error) {
+	var menus []domain.Menu
+	pattern := "%" + escapeLike(strings.ToLower(query)) + "%"
+
+	// LOWER and an explicit ESCAPE keep matching identical on every driver
+	err := r.db.WithContext(ctx).
+		Where("LOWER(name) LIKE ? ESCAPE '!' OR LOWER(code) LIKE ? ESCAPE '!' OR LOWER(route) LIKE ? ESCAPE '!'", pattern, pattern, pattern).
+		Order("level ASC, order_index ASC, id ASC").
+		Limit(limit).
+		Find(&menus).Error
+	return menus, err
+}
+
+func (r *menuRepository) CountByActive(ctx context.Context) (*domain.MenuCounts, error) {
+	var rows []struct {
+		IsActive bool
+		Total    int64
+	}
+	err := r.db.WithContext(ctx).Model(&domain.Menu{}).
+		Select("is_active, COUNT(*) AS total").
+		Group("is_active").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &domain.MenuCounts{}
+	for _, row := range rows {
+		if row.IsActive {
+			counts.Active += row.Total
+		} else {
+			counts.Inactive += row.Total
+		}
+	}
+	return counts, nil
+}
+
+func (r *menuRepository) EnqueueEvent(ctx context.Context, event *domain.OutboxEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *menuRepository) WithTx(ctx context.Context, fn func(repo domain.MenuRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&menuRepository{db: tx})
+	})
+}
+
+// translateMenuError converts GORM errors into domain errors with menu
+// specific messages
+func translateMenuError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.WrapError(domain.ErrConflict, "menu code already exists", err)
+	}
+	return translateError(err, "menu not found")
+}
+
+// parentError reports a missing parent as a validation error of the child
+func parentError(err error) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.WrapError(domain.ErrValidation, "parent menu not found", err)
+	}
+	return err
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied search term
+// using '!' as the escape character, which needs no quoting in any dialect
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,458 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/repository"
+)
+
+func TestGormMenuRepository(t *testing.T) {
+	testMenuRepository(t, func(t *testing.T) domain.MenuRepository {
+		return repository.NewMenuRepository(dbtest.Open(t))
+	})
+}
+
+func TestMemoryMenuRepository(t *testing.T) {
+	testMenuRepository(t, func(*testing.T) domain.MenuRepository {
+		return repository.NewMemoryMenuRepository()
+	})
+}
+
+// testMenuRepository is the contract every domain.MenuRepository meets.
+// newRepo returns an empty repository.
+func testMenuRepository(t *testing.T, newRepo func(t *testing.T) domain.MenuRepository) {
+	ctx := context.Background()
+
+	t.Run("Create", func(t *testing.T) {
+		repo := newRepo(t)
+		root := createMenu(t, repo, nil, "root", 0)
+		if root.ID == 0 || root.UUID == "" {
+			t.Fatalf("ID and UUID not assigned: %+v", root)
+		}
+		if root.CreatedAt.IsZero() || root.UpdatedAt.IsZero() {
+			t.Errorf("timestamps not set: %+v", root)
+		}
+
+		child := createMenu(t, repo, &root.ID, "child", 0)
+		grandchild := createMenu(t, repo, &child.ID, "grandchild", 0)
+		if child.Level != 1 || grandchild.Level != 2 {
+			t.Errorf("levels = %d, %d, want 1, 2", child.Level, grandchild.Level)
+		}
+
+		inactive := &domain.Menu{Name: "Inactive", Code: "inactive", IsActive: false}
+		if err := repo.Create(ctx, inactive); err != nil {
+			t.Fatal(err)
+		}
+		got := findMenu(t, repo, inactive.ID)
+		if got.IsActive {
+			t.Error("inactive menu stored as active")
+		}
+	})
+
+	t.Run("CreateErrors", func(t *testing.T) {
+		repo := newRepo(t)
+		createMenu(t, repo, nil, "root", 0)
+
+		err := repo.Create(ctx, &domain.Menu{Name: "Duplicate", Code: "root"})
+		assertKind(t, err, domain.ErrConflict)
+
+		missing := int64(999)
+		err = repo.Create(ctx, &domain.Menu{ParentID: &missing, Name: "Orphan", Code: "orphan"})
+		assertKind(t, err, domain.ErrValidation)
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		repo := newRepo(t)
+		menu := createMenu(t, repo, nil, "root", 0)
+
+		byUUID, err := repo.FindByUUID(ctx, menu.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if byUUID.ID != menu.ID || byUUID.Code != "root" {
+			t.Errorf("FindByUUID = %+v, want menu %d", byUUID, menu.ID)
+		}
+
+		locked, err := repo.FindByIDForUpdate(ctx, menu.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if locked.ID != menu.ID {
+			t.Errorf("FindByIDForUpdate = %d, want %d", locked.ID, menu.ID)
+		}
+
+		_, err = repo.FindByID(ctx, 999)
+		assertKind(t, err, domain.ErrNotFound)
+		_, err = repo.FindByIDForUpdate(ctx, 999)
+		assertKind(t, err, domain.ErrNotFound)
+		_, err = repo.FindByUUID(ctx, "00000000-0000-0000-0000-000000000000")
+		assertKind(t, err, domain.ErrNotFound)
+		_, err = repo.FindDetailByID(ctx, 999)
+		assertKind(t, err, domain.ErrNotFound)
+		_, err = repo.FindHierarchicalByRootID(ctx, 999)
+		assertKind(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		a := createMenu(t, repo, nil, "a", 0)
+		b := createMenu(t, repo, &a.ID, "b", 0)
+		c := createMenu(t, repo, nil, "c", 0)
+
+		// Moving c under b recalculates its level
+		c.ParentID = &b.ID
+		c.Name = "Renamed"
+		route := "/renamed"
+		c.Route = &route
+		if err := repo.Update(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		got := findMenu(t, repo, c.ID)
+		if got.Level != 2 || got.Name != "Renamed" || got.Route == nil || *got.Route != route {
+			t.Errorf("updated menu = %+v", got)
+		}
+
+		c.Code = "a"
+		assertKind(t, repo.Update(ctx, c), domain.ErrConflict)
+		c.Code = "c"
+
+		missing := int64(999)
+		c.Code, c.ParentID = "c", &missing
+		assertKind(t, repo.Update(ctx, c), domain.ErrValidation)
+	})
+
+	t.Run("UpdateMovesSubtree", func(t *testing.T) {
+		repo := newRepo(t)
+		a := createMenu(t, repo, nil, "a", 0)
+		b := createMenu(t, repo, &a.ID, "b", 0)
+		c := createMenu(t, repo, nil, "c", 0)
+		child := createMenu(t, repo, &c.ID, "child", 0)
+		grandchild := createMenu(t, repo, &child.ID, "grandchild", 0)
+
+		// Moving c under b takes its descendants two levels down
+		c.ParentID = &b.ID
+		if err := repo.Update(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		if got := findMenu(t, repo, child.ID).Level; got != 3 {
+			t.Errorf("child level = %d, want 3", got)
+		}
+		if got := findMenu(t, repo, grandchild.ID).Level; got != 4 {
+			t.Errorf("grandchild level = %d, want 4", got)
+		}
+
+		// Moving it back to the root restores them
+		c.ParentID = nil
+		if err := repo.Update(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		detail, err := repo.FindDetailByID(ctx, grandchild.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detail.Level != 2 || detail.Depth != 2 {
+			t.Errorf("grandchild level, depth = %d, %d, want 2, 2", detail.Level, detail.Depth)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := createMenu(t, repo, nil, "parent", 0)
+		child := createMenu(t, repo, &parent.ID, "child", 0)
+
+		assertKind(t, repo.Delete(ctx, parent.ID), domain.ErrHasChildren)
+
+		if err := repo.Delete(ctx, child.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Delete(ctx, parent.ID); err != nil {
+			t.Fatal(err)
+		}
+		_, err := repo.FindByID(ctx, parent.ID)
+		assertKind(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Lists", func(t *testing.T) {
+		repo := newRepo(t)
+		// Ordered by order_index, then by ID
+		second := createMenu(t, repo, nil, "second", 1)
+		first := createMenu(t, repo, nil, "first", 0)
+		third := createMenu(t, repo, nil, "third", 1)
+		x := createMenu(t, repo, &first.ID, "x", 0)
+		y := createMenu(t, repo, &second.ID, "y", 0)
+
+		all, err := repo.FindAll(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindAll", all, first.ID, x.ID, y.ID, second.ID, third.ID)
+
+		roots, err := repo.FindRootMenus(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindRootMenus", roots, first.ID, second.ID, third.ID)
+
+		roots, err = repo.FindByParentID(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindByParentID(nil)", roots, first.ID, second.ID, third.ID)
+
+		children, err := repo.FindByParentID(ctx, &first.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindByParentID", children, x.ID)
+
+		children, err = repo.FindChildrenByParentID(ctx, second.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindChildrenByParentID", children, y.ID)
+
+		children, err = repo.FindChildrenByParentIDs(ctx, []int64{first.ID, second.ID, third.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindChildrenByParentIDs", children, x.ID, y.ID)
+
+		children, err = repo.FindChildrenByParentIDs(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindChildrenByParentIDs(nil)", children)
+
+		byCode, err := repo.FindByCodes(ctx, []string{"third", "x", "missing"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindByCodes", byCode, x.ID, third.ID)
+
+		byCode, err = repo.FindByCodes(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindByCodes(nil)", byCode)
+
+		byID, err := repo.FindByIDs(ctx, []int64{third.ID, x.ID, 999})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindByIDs", byID, x.ID, third.ID)
+
+		byID, err = repo.FindByIDs(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "FindByIDs(nil)", byID)
+	})
+
+	t.Run("Hierarchy", func(t *testing.T) {
+		repo := newRepo(t)
+		a := createMenu(t, repo, nil, "a", 0)
+		b := createMenu(t, repo, nil, "b", 1)
+		a2 := createMenu(t, repo, &a.ID, "a2", 1)
+		a1 := createMenu(t, repo, &a.ID, "a1", 0)
+		a11 := createMenu(t, repo, &a1.ID, "a11", 0)
+
+		tree, err := repo.FindHierarchical(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "roots", tree, a.ID, b.ID)
+		assertIDs(t, "children of a", tree[0].Children, a1.ID, a2.ID)
+		assertIDs(t, "children of a1", tree[0].Children[0].Children, a11.ID)
+		if tree[1].Children != nil || tree[0].Children[1].Children != nil {
+			t.Error("leaves have non-nil children")
+		}
+
+		subtree, err := repo.FindHierarchicalByRootID(ctx, a1.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "subtree", subtree, a1.ID)
+		assertIDs(t, "subtree children", subtree[0].Children, a11.ID)
+
+		// Plain lookups do not load children
+		if got := findMenu(t, repo, a.ID); got.Children != nil {
+			t.Errorf("FindByID loaded %d children", len(got.Children))
+		}
+	})
+
+	t.Run("Detail", func(t *testing.T) {
+		repo := newRepo(t)
+		parent := createMenu(t, repo, nil, "parent", 0)
+		child := createMenu(t, repo, &parent.ID, "child", 0)
+
+		detail, err := repo.FindDetailByID(ctx, child.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detail.ID != child.ID || detail.Depth != 1 {
+			t.Errorf("detail = %+v", detail)
+		}
+		want := domain.MenuParentInfo{ID: parent.ID, UUID: parent.UUID, Name: parent.Name, Code: parent.Code}
+		if detail.ParentData == nil || *detail.ParentData != want {
+			t.Errorf("parent data = %+v, want %+v", detail.ParentData, want)
+		}
+
+		detail, err = repo.FindDetailByID(ctx, parent.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detail.ParentData != nil || detail.Depth != 0 {
+			t.Errorf("root detail = %+v", detail)
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		repo := newRepo(t)
+		users := createMenu(t, repo, nil, "users", 1)
+		reports := createMenu(t, repo, nil, "reports", 0)
+		userList := createMenu(t, repo, &reports.ID, "user-reports", 0)
+		percent := createMenu(t, repo, nil, "100_percent", 2)
+		route := "/admin/users"
+		routed := &domain.Menu{Name: "Admin", Code: "admin", Route: &route}
+		if err := repo.Create(ctx, routed); err != nil {
+			t.Fatal(err)
+		}
+
+		// Matches name, code or route without regard to case, ordered by
+		// level, then order_index
+		found, err := repo.Search(ctx, "USER", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "Search", found, routed.ID, users.ID, userList.ID)
+
+		found, err = repo.Search(ctx, "user", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "Search with limit", found, routed.ID, users.ID)
+
+		// LIKE wildcards in the query match literally
+		found, err = repo.Search(ctx, "0_p", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "Search underscore", found, percent.ID)
+
+		found, err = repo.Search(ctx, "%", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, "Search percent", found)
+	})
+
+	t.Run("CountByActive", func(t *testing.T) {
+		repo := newRepo(t)
+		createMenu(t, repo, nil, "a", 0)
+		createMenu(t, repo, nil, "b", 0)
+		if err := repo.Create(ctx, &domain.Menu{Name: "C", Code: "c"}); err != nil {
+			t.Fatal(err)
+		}
+
+		counts, err := repo.CountByActive(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *counts != (domain.MenuCounts{Active: 2, Inactive: 1}) {
+			t.Errorf("counts = %+v, want 2 active and 1 inactive", counts)
+		}
+	})
+
+	t.Run("EnqueueEvent", func(t *testing.T) {
+		repo := newRepo(t)
+		event := &domain.OutboxEvent{EventType: domain.MenuEventCreated, MenuID: 1, AffectedIDs: []int64{1}}
+		if err := repo.EnqueueEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+		if event.ID == 0 {
+			t.Error("event ID not assigned")
+		}
+	})
+
+	t.Run("WithTx", func(t *testing.T) {
+		repo := newRepo(t)
+
+		var committed *domain.Menu
+		err := repo.WithTx(ctx, func(tx domain.MenuRepository) error {
+			committed = createMenu(t, tx, nil, "committed", 0)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		findMenu(t, repo, committed.ID)
+
+		rollback := errors.New("rollback")
+		var rolledBack *domain.Menu
+		err = repo.WithTx(ctx, func(tx domain.MenuRepository) error {
+			rolledBack = createMenu(t, tx, nil, "rolled-back", 0)
+			committed.Name = "Changed"
+			if err := tx.Update(ctx, committed); err != nil {
+				return err
+			}
+			return rollback
+		})
+		if !errors.Is(err, rollback) {
+			t.Fatalf("WithTx error = %v, want %v", err, rollback)
+		}
+		_, err = repo.FindByID(ctx, rolledBack.ID)
+		assertKind(t, err, domain.ErrNotFound)
+		if got := findMenu(t, repo, committed.ID); got.Name != "Committed" {
+			t.Errorf("rolled back update kept: name = %q", got.Name)
+		}
+	})
+}
+
+// createMenu creates an active menu named after its code
+func createMenu(t *testing.T, repo domain.MenuRepository, parentID *int64, code string, order int) *domain.Menu {
+	t.Helper()
+	menu := &domain.Menu{
+		ParentID:   parentID,
+		Name:       strings.ToUpper(code[:1]) + code[1:],
+		Code:       code,
+		OrderIndex: order,
+		IsActive:   true,
+	}
+	if err := repo.Create(context.Background(), menu); err != nil {
+		t.Fatalf("failed to create menu %q: %v", code, err)
+	}
+	return menu
+}
+
+func findMenu(t *testing.T, repo domain.MenuRepository, id int64) *domain.Menu {
+	t.Helper()
+	menu, err := repo.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("failed to find menu %d: %v", id, err)
+	}
+	return menu
+}
+
+func assertKind(t *testing.T, err, kind error) {
+	t.Helper()
+	if !errors.Is(err, kind) {
+		t.Errorf("error = %v, want kind %v", err, kind)
+	}
+}
+
+func assertIDs(t *testing.T, name string, menus []domain.Menu, want ...int64) {
+	t.Helper()
+	got := make([]int64, len(menus))
+	for i, m := range menus {
+		got[i] = m.ID
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("%s returned menus %v, want %v", name, got, want)
+	}
+}
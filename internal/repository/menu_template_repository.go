@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"stk-technical-test-api/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type menuTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewMenuTemplateRepository creates a new menu template repository instance
+func NewMenuTemplateRepository(db *gorm.DB) domain.MenuTemplateRepository {
+	return &menuTemplateRepository{
+		db: db,
+	}
+}
+
+func (r *menuTemplateRepository) Create(ctx context.Context, tmpl *domain.MenuTemplate) error {
+	return translateTemplateError(r.db.WithContext(ctx).Create(tmpl).Error)
+}
+
+func (r *menuTemplateRepository) Update(ctx context.Context, tmpl *domain.MenuTemplate) error {
+	return translateTemplateError(r.db.WithContext(ctx).Save(tmpl).Error)
+}
+
+func (r *menuTemplateRepository) Delete(ctx context.Context, id int64) error {
+	return translateTemplateError(r.db.WithContext(ctx).Delete(&domain.MenuTemplate{}, id).Error)
+}
+
+func (r *menuTemplateRepository) FindByName(ctx context.Context, name string) (*domain.MenuTemplate, error) {
+	var tmpl domain.MenuTemplate
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&tmpl).Error
+	if err != nil {
+		return nil, translateTemplateError(err)
+	}
+	return &tmpl, nil
+}
+
+func (r *menuTemplateRepository) FindAll(ctx context.Context) ([]domain.MenuTemplate, error) {
+	var templates []domain.MenuTemplate
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// translateTemplateError converts GORM errors into domain errors with
+// template specific messages
+func translateTemplateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.WrapError(domain.ErrConflict, "menu template name already exists", err)
+	}
+	return translateError(err, "menu template not found")
+}
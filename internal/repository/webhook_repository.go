@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *gorm.DB) domain.WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	// Generate UUID
+	sub.UUID = uuid.New().String()
+
+	return r.db.WithContext(ctx).Create(sub).Error
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Save(sub).Error
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Remove the delivery log together with the subscription
+		if err := tx.Where("subscription_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.WebhookSubscription{}, id).Error
+	})
+}
+
+func (r *webhookRepository) FindSubscriptionByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	err := r.db.WithContext(ctx).First(&sub, id).Error
+	if err != nil {
+		return nil, translateError(err, "webhook not found")
+	}
+	return &sub, nil
+}
+
+func (r *webhookRepository) FindAllSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	var subs []domain.WebhookSubscription
+	err := r.db.WithContext(ctx).Order("id ASC").Find(&subs).Error
+	return subs, err
+}
+
+func (r *webhookRepository) FindDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) DispatchPendingEvents(ctx context.Context, limit int, now time.Time) (int, error) {
+	dispatched := 0
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var events []domain.OutboxEvent
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dispatched_at IS NULL").
+			Order("id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		var subs []domain.WebhookSubscription
+		if err := tx.Where("is_active = ?", true).Find(&subs).Error; err != nil {
+			return err
+		}
+
+		var deliveries []domain.WebhookDelivery
+		ids := make([]int64, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.ID)
+			for _, sub := range subs {
+				if !sub.Matches(event.EventType) {
+					continue
+				}
+				deliveries = append(deliveries, domain.WebhookDelivery{
+					SubscriptionID: sub.ID,
+					OutboxEventID:  event.ID,
+					EventType:      event.EventType,
+					Status:         domain.DeliveryStatusPending,
+					NextAttemptAt:  now,
+				})
+			}
+		}
+
+		if len(deliveries) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&deliveries).Error; err != nil {
+				return err
+			}
+		}
+
+		dispatched = len(events)
+		return tx.Model(&domain.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("dispatched_at", now).Error
+	})
+
+	return dispatched, err
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	var ids []int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.DeliveryStatusPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		return tx.Model(&domain.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	// Load the subscription and event needed to send each delivery
+	var deliveries []domain.WebhookDelivery
+	err = r.db.WithContext(ctx).Preload("Subscription").
+		Preload("OutboxEvent").
+		Where("id IN ?", ids).
+		Order("id ASC").
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(delivery).Error
+}
+
+func (r *webhookRepository) PurgeDispatchedEvents(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Delete the finished deliveries explicitly; SQLite only cascades
+		// with foreign keys enabled
+		expired := tx.Model(&domain.OutboxEvent{}).Select("id").Where("dispatched_at < ?", before)
+		err := tx.Where("outbox_event_id IN (?) AND status <> ?", expired, domain.DeliveryStatusPending).
+			Delete(&domain.WebhookDelivery{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("dispatched_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM webhook_deliveries WHERE webhook_deliveries.outbox_event_id = outbox_events.id)").
+			Delete(&domain.OutboxEvent{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+
+	return purged, err
+}
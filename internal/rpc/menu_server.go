@@ -0,0 +1,298 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/event"
+	menuv1 "stk-technical-test-api/pkg/pb/menu/v1"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var eventTypes = map[domain.MenuEventType]menuv1.MenuEventType{
+	domain.MenuEventCreated:   menuv1.MenuEventType_MENU_EVENT_TYPE_CREATED,
+	domain.MenuEventUpdated:   menuv1.MenuEventType_MENU_EVENT_TYPE_UPDATED,
+	domain.MenuEventMoved:     menuv1.MenuEventType_MENU_EVENT_TYPE_MOVED,
+	domain.MenuEventDeleted:   menuv1.MenuEventType_MENU_EVENT_TYPE_DELETED,
+	domain.MenuEventReordered: menuv1.MenuEventType_MENU_EVENT_TYPE_REORDERED,
+}
+
+// MenuServer implements the gRPC MenuService on top of domain.MenuService
+type MenuServer struct {
+	menuv1.UnimplementedMenuServiceServer
+
+	service domain.MenuService
+	broker  *event.Broker
+}
+
+// NewMenuServer creates a new gRPC menu server
+func NewMenuServer(service domain.MenuService, broker *event.Broker) *MenuServer {
+	return &MenuServer{
+		service: service,
+		broker:  broker,
+	}
+}
+
+// NewServer creates a gRPC server with the menu service and reflection registered
+func NewServer(menuServer *MenuServer, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	menuv1.RegisterMenuServiceServer(server, menuServer)
+	reflection.Register(server)
+	return server
+}
+
+func (s *MenuServer) CreateMenu(ctx context.Context, req *menuv1.CreateMenuRequest) (*menuv1.Menu, error) {
+	menu, err := s.service.CreateMenu(ctx, &domain.CreateMenuRequest{
+		ParentID:    req.ParentId,
+		Name:        req.GetName(),
+		Code:        req.GetCode(),
+		Description: req.Description,
+		Route:       req.Route,
+		Icon:        req.Icon,
+		OrderIndex:  int(req.GetOrderIndex()),
+		IsActive:    req.GetIsActive(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoMenu(menu), nil
+}
+
+func (s *MenuServer) UpdateMenu(ctx context.Context, req *menuv1.UpdateMenuRequest) (*menuv1.Menu, error) {
+	menu, err := s.service.UpdateMenu(ctx, req.GetId(), &domain.UpdateMenuRequest{
+		ParentID:    req.ParentId,
+		Name:        req.GetName(),
+		Code:        req.GetCode(),
+		Description: req.Description,
+		Route:       req.Route,
+		Icon:        req.Icon,
+		OrderIndex:  int(req.GetOrderIndex()),
+		IsActive:    req.GetIsActive(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoMenu(menu), nil
+}
+
+func (s *MenuServer) DeleteMenu(ctx context.Context, req *menuv1.DeleteMenuRequest) (*menuv1.DeleteMenuResponse, error) {
+	if err := s.service.DeleteMenu(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &menuv1.DeleteMenuResponse{}, nil
+}
+
+func (s *MenuServer) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest) (*menuv1.Menu, error) {
+	menu, err := s.service.GetMenuByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoMenu(menu), nil
+}
+
+func (s *MenuServer) GetMenuByUUID(ctx context.Context, req *menuv1.GetMenuByUUIDRequest) (*menuv1.Menu, error) {
+	if req.GetUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "uuid is required")
+	}
+
+	menu, err := s.service.GetMenuByUUID(ctx, req.GetUuid())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoMenu(menu), nil
+}
+
+func (s *MenuServer) GetMenuDetail(ctx context.Context, req *menuv1.GetMenuDetailRequest) (*menuv1.MenuDetail, error) {
+	detail, err := s.service.GetMenuDetail(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &menuv1.MenuDetail{
+		Menu:  toProtoMenu(&detail.Menu),
+		Depth: int32(detail.Depth),
+	}
+	if detail.ParentData != nil {
+		resp.Parent = &menuv1.MenuParentInfo{
+			Id:   detail.ParentData.ID,
+			Uuid: detail.ParentData.UUID,
+			Name: detail.ParentData.Name,
+			Code: detail.ParentData.Code,
+		}
+	}
+	return resp, nil
+}
+
+func (s *MenuServer) ListMenus(ctx context.Context, req *menuv1.ListMenusRequest) (*menuv1.ListMenusResponse, error) {
+	menus, err := s.service.GetAllMenus(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoList(menus), nil
+}
+
+func (s *MenuServer) ListRootMenus(ctx context.Context, req *menuv1.ListRootMenusRequest) (*menuv1.ListMenusResponse, error) {
+	menus, err := s.service.GetRootMenus(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoList(menus), nil
+}
+
+func (s *MenuServer) ListChildren(ctx context.Context, req *menuv1.ListChildrenRequest) (*menuv1.ListMenusResponse, error) {
+	menus, err := s.service.GetChildrenByParentID(ctx, req.GetParentId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoList(menus), nil
+}
+
+func (s *MenuServer) GetMenuHierarchy(ctx context.Context, req *menuv1.GetMenuHierarchyRequest) (*menuv1.ListMenusResponse, error) {
+	var (
+		menus []domain.Menu
+		err   error
+	)
+	if req.RootId != nil {
+		menus, err = s.service.GetHierarchyByRootID(ctx, req.GetRootId())
+	} else {
+		menus, err = s.service.GetMenuHierarchy(ctx)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoList(menus), nil
+}
+
+func (s *MenuServer) SearchMenus(ctx context.Context, req *menuv1.SearchMenusRequest) (*menuv1.ListMenusResponse, error) {
+	menus, err := s.service.SearchMenus(ctx, req.GetQuery(), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoList(menus), nil
+}
+
+func (s *MenuServer) WatchMenus(req *menuv1.WatchMenusRequest, stream grpc.ServerStreamingServer[menuv1.MenuEvent]) error {
+	sub := s.broker.Subscribe(req.GetLastVersion())
+	defer s.broker.Unsubscribe(sub)
+
+	if sub.Gap {
+		// The requested version fell out of the buffer; tell the client to reload
+		err := stream.Send(&menuv1.MenuEvent{
+			Version:    s.broker.Version(),
+			Type:       menuv1.MenuEventType_MENU_EVENT_TYPE_RESET,
+			OccurredAt: timestamppb.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, e := range sub.Backlog {
+			if err := stream.Send(toProtoEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-sub.Events:
+			if !ok {
+				return status.Error(codes.Aborted, "event stream closed, resume with last_version")
+			}
+			if err := stream.Send(toProtoEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toStatus converts a service error to a gRPC status based on its domain kind
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrHasChildren):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrValidation), errors.Is(err, domain.ErrCycle):
+		return invalidArgument(err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// invalidArgument builds an InvalidArgument status carrying the field
+// violations of err as BadRequest details
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || len(domainErr.Violations) == 0 {
+		return st.Err()
+	}
+
+	details := &errdetails.BadRequest{}
+	for _, v := range domainErr.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Message,
+		})
+	}
+	if withDetails, detailErr := st.WithDetails(details); detailErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func toProtoMenu(menu *domain.Menu) *menuv1.Menu {
+	pb := &menuv1.Menu{
+		Id:          menu.ID,
+		Uuid:        menu.UUID,
+		ParentId:    menu.ParentID,
+		Name:        menu.Name,
+		Code:        menu.Code,
+		Description: menu.Description,
+		Route:       menu.Route,
+		Icon:        menu.Icon,
+		OrderIndex:  int32(menu.OrderIndex),
+		Level:       int32(menu.Level),
+		IsActive:    menu.IsActive,
+		CreatedAt:   timestamppb.New(menu.CreatedAt),
+		UpdatedAt:   timestamppb.New(menu.UpdatedAt),
+	}
+	for i := range menu.Children {
+		pb.Children = append(pb.Children, toProtoMenu(&menu.Children[i]))
+	}
+	return pb
+}
+
+func toProtoList(menus []domain.Menu) *menuv1.ListMenusResponse {
+	resp := &menuv1.ListMenusResponse{Menus: make([]*menuv1.Menu, 0, len(menus))}
+	for i := range menus {
+		resp.Menus = append(resp.Menus, toProtoMenu(&menus[i]))
+	}
+	return resp
+}
+
+func toProtoEvent(e domain.MenuEvent) *menuv1.MenuEvent {
+	return &menuv1.MenuEvent{
+		Version:     e.Version,
+		Type:        eventTypes[e.Type],
+		MenuId:      e.MenuID,
+		AffectedIds: e.AffectedIDs,
+		OccurredAt:  timestamppb.New(e.OccurredAt),
+	}
+}
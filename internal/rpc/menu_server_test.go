@@ -0,0 +1,259 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/event"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/service"
+	menuv1 "stk-technical-test-api/pkg/pb/menu/v1"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// dial serves a MenuServer for service over an in-memory connection, with
+// the given request timeout, and returns a client for it
+func dial(t *testing.T, svc domain.MenuService, broker *event.Broker, timeout time.Duration) menuv1.MenuServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(NewMenuServer(svc, broker), grpc.UnaryInterceptor(TimeoutInterceptor(timeout)))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return menuv1.NewMenuServiceClient(conn)
+}
+
+// newTestClient returns a client for a server on the in-memory repository
+func newTestClient(t *testing.T) (menuv1.MenuServiceClient, *event.Broker) {
+	t.Helper()
+	broker := event.NewBroker(16)
+	t.Cleanup(broker.Close)
+	svc := service.NewMenuService(repository.NewMemoryMenuRepository(), broker, domain.MenuLimits{})
+	return dial(t, svc, broker, time.Minute), broker
+}
+
+func createMenu(t *testing.T, client menuv1.MenuServiceClient, req *menuv1.CreateMenuRequest) *menuv1.Menu {
+	t.Helper()
+	menu, err := client.CreateMenu(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return menu
+}
+
+func TestMenuServerStatusCodes(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	parent := createMenu(t, client, &menuv1.CreateMenuRequest{Name: "Settings", Code: "settings", IsActive: true})
+	child := createMenu(t, client, &menuv1.CreateMenuRequest{ParentId: proto.Int64(parent.Id), Name: "Users", Code: "users", IsActive: true})
+
+	tests := []struct {
+		name  string
+		call  func() error
+		want  codes.Code
+		field string
+	}{
+		{
+			name: "missing menu",
+			call: func() error {
+				_, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{Id: 999})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "duplicate code",
+			call: func() error {
+				_, err := client.CreateMenu(ctx, &menuv1.CreateMenuRequest{Name: "Preferences", Code: "settings"})
+				return err
+			},
+			want: codes.AlreadyExists,
+		},
+		{
+			name: "delete with children",
+			call: func() error {
+				_, err := client.DeleteMenu(ctx, &menuv1.DeleteMenuRequest{Id: parent.Id})
+				return err
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			name: "missing name",
+			call: func() error {
+				_, err := client.CreateMenu(ctx, &menuv1.CreateMenuRequest{Code: "reports"})
+				return err
+			},
+			want:  codes.InvalidArgument,
+			field: "name",
+		},
+		{
+			name: "move under own child",
+			call: func() error {
+				_, err := client.UpdateMenu(ctx, &menuv1.UpdateMenuRequest{
+					Id: parent.Id, ParentId: proto.Int64(child.Id), Name: "Settings", Code: "settings", IsActive: true,
+				})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "missing uuid",
+			call: func() error {
+				_, err := client.GetMenuByUUID(ctx, &menuv1.GetMenuByUUIDRequest{})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(tt.call())
+			if st.Code() != tt.want {
+				t.Fatalf("code = %s (%s), want %s", st.Code(), st.Message(), tt.want)
+			}
+			if tt.field == "" {
+				return
+			}
+			for _, detail := range st.Details() {
+				if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+					for _, v := range badRequest.FieldViolations {
+						if v.Field == tt.field {
+							return
+						}
+					}
+				}
+			}
+			t.Errorf("details = %v, want a violation of %s", st.Details(), tt.field)
+		})
+	}
+}
+
+// slowService blocks GetMenuByID until the request context ends
+type slowService struct {
+	domain.MenuService
+}
+
+func (slowService) GetMenuByID(ctx context.Context, id int64) (*domain.Menu, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestMenuServerAppliesRequestTimeout(t *testing.T) {
+	broker := event.NewBroker(16)
+	t.Cleanup(broker.Close)
+	client := dial(t, slowService{}, broker, 50*time.Millisecond)
+
+	// The client allows far longer than the server timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{Id: 1})
+	if code := status.Code(err); code != codes.DeadlineExceeded {
+		t.Fatalf("code = %s, want %s", code, codes.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("call took %s, want it cut off by the server timeout", elapsed)
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	deadline := func(ctx context.Context, _ any) (any, error) {
+		d, ok := ctx.Deadline()
+		if !ok {
+			return time.Duration(0), nil
+		}
+		return time.Until(d), nil
+	}
+	call := func(ctx context.Context, timeout time.Duration) time.Duration {
+		left, _ := TimeoutInterceptor(timeout)(ctx, nil, &grpc.UnaryServerInfo{}, deadline)
+		return left.(time.Duration)
+	}
+
+	if left := call(context.Background(), time.Minute); left <= 0 || left > time.Minute {
+		t.Errorf("deadline in %s, want within a minute", left)
+	}
+	if left := call(context.Background(), 0); left != 0 {
+		t.Errorf("deadline in %s with a zero timeout, want none", left)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if left := call(ctx, time.Minute); left > time.Second {
+		t.Errorf("deadline in %s, want the earlier client deadline", left)
+	}
+}
+
+func TestWatchMenus(t *testing.T) {
+	client, broker := newTestClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	first := createMenu(t, client, &menuv1.CreateMenuRequest{Name: "Settings", Code: "settings", IsActive: true})
+	second := createMenu(t, client, &menuv1.CreateMenuRequest{Name: "Reports", Code: "reports", IsActive: true})
+
+	// Resuming after the first event replays the second from the backlog
+	stream, err := client.WatchMenus(ctx, &menuv1.WatchMenusRequest{LastVersion: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Version != 2 || e.Type != menuv1.MenuEventType_MENU_EVENT_TYPE_CREATED || e.MenuId != second.Id {
+		t.Errorf("backlog event = %v, want the creation of menu %d", e, second.Id)
+	}
+
+	// Later events are streamed as they happen
+	if _, err := client.DeleteMenu(ctx, &menuv1.DeleteMenuRequest{Id: first.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if e, err = stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if e.Version != 3 || e.Type != menuv1.MenuEventType_MENU_EVENT_TYPE_DELETED || e.MenuId != first.Id {
+		t.Errorf("live event = %v, want the deletion of menu %d", e, first.Id)
+	}
+
+	// A version the server has not reached, e.g. from before a restart, resets the client
+	reset, err := client.WatchMenus(ctx, &menuv1.WatchMenusRequest{LastVersion: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, err = reset.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != menuv1.MenuEventType_MENU_EVENT_TYPE_RESET || e.Version != broker.Version() {
+		t.Errorf("event = %v, want a reset at version %d", e, broker.Version())
+	}
+
+	// Closing the broker ends the stream with Aborted
+	broker.Close()
+	if _, err = stream.Recv(); status.Code(err) != codes.Aborted {
+		t.Errorf("err = %v, want %s", err, codes.Aborted)
+	}
+}
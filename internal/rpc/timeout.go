@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// TimeoutInterceptor bounds the context of unary calls with a deadline, like
+// the REST timeout middleware, so that database work is cancelled once it
+// expires. A deadline set by the client is kept when it is earlier. Streams
+// such as WatchMenus are long-lived and stay unbounded, as is every call when
+// timeout is zero.
+func TimeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,69 @@
+package seed
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// Generate builds a synthetic tree of size menus spread over depth levels.
+// Every menu gets the same number of children, the smallest number that
+// fits size menus within depth levels, and the tree is filled depth first,
+// so the first branch always reaches the full depth. Codes are derived from
+// the position in the tree, e.g. large-2-1-3, which keeps seeding repeatable.
+func Generate(prefix string, size, depth int, limits domain.MenuLimits) ([]Node, error) {
+	if size < 1 || depth < 1 {
+		return nil, fmt.Errorf("size and depth must be positive")
+	}
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return nil, fmt.Errorf("depth %d exceeds the maximum menu depth of %d", depth, limits.MaxDepth)
+	}
+
+	branching := 1
+	for capacity(branching, depth, size) < size {
+		branching++
+	}
+	if limits.MaxChildren > 0 && depth > 1 && branching > limits.MaxChildren {
+		return nil, fmt.Errorf("%d menus need %d children per menu over %d levels, above the limit of %d",
+			size, branching, depth, limits.MaxChildren)
+	}
+
+	remaining := size
+	var build func(path []string, level int) []Node
+	build = func(path []string, level int) []Node {
+		var nodes []Node
+		for i := 1; i <= branching && remaining > 0; i++ {
+			remaining--
+
+			position := append(path[:len(path):len(path)], strconv.Itoa(i))
+			route := "/" + prefix + "/" + strings.Join(position, "/")
+			node := Node{
+				Name:       prefix + " " + strings.Join(position, "."),
+				Code:       prefix + "-" + strings.Join(position, "-"),
+				Route:      &route,
+				OrderIndex: i,
+				IsActive:   true,
+			}
+			if level < depth {
+				node.Children = build(position, level+1)
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	return build(nil, 1), nil
+}
+
+// capacity returns how many menus fit in depth levels with branching
+// children per menu, stopping early once it reaches limit
+func capacity(branching, depth, limit int) int {
+	total, level := 0, 1
+	for i := 0; i < depth && total < limit; i++ {
+		level *= branching
+		total += level
+	}
+	return total
+}
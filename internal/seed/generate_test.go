@@ -0,0 +1,94 @@
+package seed
+
+import (
+	"testing"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// shape returns the depth of the trees and the most children of any menu,
+// roots included
+func shape(nodes []Node) (depth, children int) {
+	children = len(nodes)
+	for _, node := range nodes {
+		d, c := shape(node.Children)
+		depth = max(depth, d)
+		children = max(children, c)
+	}
+	if len(nodes) > 0 {
+		depth++
+	}
+	return depth, children
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name         string
+		size, depth  int
+		limits       domain.MenuLimits
+		wantChildren int
+	}{
+		{name: "single menu", size: 1, depth: 3, wantChildren: 1},
+		{name: "flat", size: 5, depth: 1, wantChildren: 5},
+		{name: "chain", size: 3, depth: 3, wantChildren: 1},
+		{name: "binary", size: 7, depth: 3, wantChildren: 2},
+		{name: "partly filled", size: 15, depth: 3, wantChildren: 3},
+		{name: "large defaults", size: 1000, depth: 3, wantChildren: 10},
+		{name: "within limits", size: 100, depth: 4, limits: domain.MenuLimits{MaxDepth: 4, MaxChildren: 3}, wantChildren: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := Generate("test", tt.size, tt.depth, tt.limits)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := Count(nodes); got != tt.size {
+				t.Errorf("%d menus, want %d", got, tt.size)
+			}
+			// The first branch is filled first, so it reaches the full depth
+			depth, children := shape(nodes)
+			if want := min(tt.depth, tt.size); depth != want {
+				t.Errorf("depth = %d, want %d", depth, want)
+			}
+			if children != tt.wantChildren {
+				t.Errorf("at most %d children per menu, want %d", children, tt.wantChildren)
+			}
+
+			codes := map[string]bool{}
+			var walk func([]Node)
+			walk = func(nodes []Node) {
+				for _, node := range nodes {
+					if codes[node.Code] {
+						t.Errorf("code %s generated twice", node.Code)
+					}
+					codes[node.Code] = true
+					walk(node.Children)
+				}
+			}
+			walk(nodes)
+		})
+	}
+}
+
+func TestGenerateRejectsImpossibleTrees(t *testing.T) {
+	tests := []struct {
+		name        string
+		size, depth int
+		limits      domain.MenuLimits
+	}{
+		{name: "no menus", size: 0, depth: 3},
+		{name: "no levels", size: 10, depth: 0},
+		{name: "too deep", size: 10, depth: 5, limits: domain.MenuLimits{MaxDepth: 4}},
+		{name: "too many children", size: 100, depth: 2, limits: domain.MenuLimits{MaxChildren: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if nodes, err := Generate("test", tt.size, tt.depth, tt.limits); err == nil {
+				t.Errorf("generated %d menus, want an error", Count(nodes))
+			}
+		})
+	}
+}
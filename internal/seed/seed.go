@@ -0,0 +1,173 @@
+// Package seed loads menu fixtures for local development and performance
+// work. Fixtures are trees of menus identified by their code.
+package seed
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+//go:embed fixtures/*.json
+var fixtureFiles embed.FS
+
+// Node is a menu together with its children. It carries no IDs, so a tree
+// can be created under any parent or in another database. This is also the
+// format read and written by menuctl import and export.
+type Node struct {
+	Name        string  `json:"name"`
+	Code        string  `json:"code"`
+	Description *string `json:"description,omitempty"`
+	Route       *string `json:"route,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+	OrderIndex  int     `json:"order_index"`
+	IsActive    bool    `json:"is_active"`
+	Children    []Node  `json:"children,omitempty"`
+}
+
+// Options sizes the generated fixtures. Zero values use the fixture defaults.
+type Options struct {
+	Size  int
+	Depth int
+}
+
+// Fixtures lists the names accepted by Load
+var Fixtures = []string{"default", "large", "deep"}
+
+// Load returns the menus of the named fixture. The default fixture is the
+// sample hierarchy from the Postman collection; large and deep are generated.
+func Load(name string, opts Options, limits domain.MenuLimits) ([]Node, error) {
+	switch name {
+	case "default":
+		return readFixture(name)
+	case "large":
+		return Generate(name, withDefault(opts.Size, 1000), withDefault(opts.Depth, 3), limits)
+	case "deep":
+		depth := limits.MaxDepth
+		if depth == 0 {
+			depth = 25
+		}
+		return Generate(name, withDefault(opts.Size, 100), withDefault(opts.Depth, depth), limits)
+	}
+	return nil, fmt.Errorf("unknown fixture %q, expected one of %v", name, Fixtures)
+}
+
+func readFixture(name string) ([]Node, error) {
+	content, err := fixtureFiles.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []Node
+	if err := json.Unmarshal(content, &nodes); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", name, err)
+	}
+	return nodes, nil
+}
+
+func withDefault(value, fallback int) int {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// FromMenus converts a menu hierarchy into nodes
+func FromMenus(menus []domain.Menu) []Node {
+	nodes := make([]Node, 0, len(menus))
+	for _, m := range menus {
+		nodes = append(nodes, Node{
+			Name:        m.Name,
+			Code:        m.Code,
+			Description: m.Description,
+			Route:       m.Route,
+			Icon:        m.Icon,
+			OrderIndex:  m.OrderIndex,
+			IsActive:    m.IsActive,
+			Children:    FromMenus(m.Children),
+		})
+	}
+	return nodes
+}
+
+// Count returns the number of nodes in the trees, children included
+func Count(nodes []Node) int {
+	count := len(nodes)
+	for _, node := range nodes {
+		count += Count(node.Children)
+	}
+	return count
+}
+
+// Result counts the menus handled by Seed
+type Result struct {
+	Created int
+	Skipped int
+}
+
+// Seeder creates fixture menus through the menu service, so the usual
+// validation and tree limits apply
+type Seeder struct {
+	service  domain.MenuService
+	existing map[string]int64
+}
+
+// NewSeeder creates a seeder
+func NewSeeder(service domain.MenuService) *Seeder {
+	return &Seeder{service: service}
+}
+
+// Seed creates the menus of nodes that do not exist yet. Menus are matched by
+// code: an existing menu is left unchanged and its missing children are still
+// created under it, so seeding the same fixture again is a no-op.
+func (s *Seeder) Seed(ctx context.Context, nodes []Node) (Result, error) {
+	var result Result
+
+	if s.existing == nil {
+		menus, err := s.service.GetAllMenus(ctx)
+		if err != nil {
+			return result, err
+		}
+		s.existing = make(map[string]int64, len(menus))
+		for _, menu := range menus {
+			s.existing[menu.Code] = menu.ID
+		}
+	}
+
+	err := s.seed(ctx, nodes, nil, &result)
+	return result, err
+}
+
+func (s *Seeder) seed(ctx context.Context, nodes []Node, parentID *int64, result *Result) error {
+	for _, node := range nodes {
+		id, ok := s.existing[node.Code]
+		if ok {
+			result.Skipped++
+		} else {
+			menu, err := s.service.CreateMenu(ctx, &domain.CreateMenuRequest{
+				ParentID:    parentID,
+				Name:        node.Name,
+				Code:        node.Code,
+				Description: node.Description,
+				Route:       node.Route,
+				Icon:        node.Icon,
+				OrderIndex:  node.OrderIndex,
+				IsActive:    node.IsActive,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to seed menu %q: %w", node.Code, err)
+			}
+			id = menu.ID
+			s.existing[node.Code] = id
+			result.Created++
+		}
+
+		if err := s.seed(ctx, node.Children, &id, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/repository"
+	"stk-technical-test-api/internal/service"
+)
+
+func newMenuService() domain.MenuService {
+	return service.NewMenuService(repository.NewMemoryMenuRepository(), nil, domain.MenuLimits{})
+}
+
+func TestSeedTwiceCreatesNothing(t *testing.T) {
+	ctx := context.Background()
+	menus := newMenuService()
+
+	nodes, err := Load("default", Options{}, domain.MenuLimits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := Count(nodes)
+
+	result, err := NewSeeder(menus).Seed(ctx, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Result{Created: total}); result != want {
+		t.Errorf("first seed = %+v, want %+v", result, want)
+	}
+
+	// A new seeder, as in a second run of the seed command, finds every code
+	result, err = NewSeeder(menus).Seed(ctx, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Result{Skipped: total}); result != want {
+		t.Errorf("second seed = %+v, want %+v", result, want)
+	}
+
+	all, err := menus.GetAllMenus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != total {
+		t.Errorf("%d menus stored, want %d", len(all), total)
+	}
+}
+
+func TestSeedCompletesExistingMenus(t *testing.T) {
+	ctx := context.Background()
+	menus := newMenuService()
+
+	nodes, err := Generate("large", 7, 3, domain.MenuLimits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first root exists already, with a name of its own
+	root, err := menus.CreateMenu(ctx, &domain.CreateMenuRequest{Name: "Renamed", Code: nodes[0].Code, IsActive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := NewSeeder(menus).Seed(ctx, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Result{Created: 6, Skipped: 1}); result != want {
+		t.Errorf("seed = %+v, want %+v", result, want)
+	}
+
+	// The existing menu is left unchanged and its children are created under it
+	got, err := menus.GetMenuByID(ctx, root.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Renamed" {
+		t.Errorf("existing menu renamed to %q", got.Name)
+	}
+	children, err := menus.GetChildrenByParentID(ctx, root.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != len(nodes[0].Children) {
+		t.Errorf("%d children under the existing menu, want %d", len(children), len(nodes[0].Children))
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// CloneMenu copies the menu with the given ID and all its descendants under
+// req.ParentID. The copies get new UUIDs, levels and rewritten codes. Every
+// problem, including codes that are already taken, is reported before
+// anything is written.
+func (s *menuService) CloneMenu(ctx context.Context, id int64, req *domain.CloneMenuRequest) (*domain.Menu, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	var (
+		clone  *domain.Menu
+		events []domain.MenuEvent
+	)
+	err := s.repo.WithTx(ctx, func(repo domain.MenuRepository) error {
+		// Lock the parent so it cannot be deleted or filled up concurrently
+		if err := lockParent(ctx, repo, req.ParentID); err != nil {
+			return err
+		}
+
+		tree, err := repo.FindHierarchicalByRootID(ctx, id)
+		if err != nil {
+			return err
+		}
+		source := tree[0]
+		if req.Name != "" {
+			source.Name = req.Name
+		}
+
+		violations := s.validator.fieldViolations(req)
+		rewrite, ruleViolations := codeRewriter(req)
+		violations = append(violations, ruleViolations...)
+
+		treeViolations, err := s.validator.treeViolations(ctx, repo, menuPlacement{
+			parentID: req.ParentID,
+			name:     source.Name,
+			moved:    true,
+			renamed:  true,
+			height:   subtreeHeight(tree),
+		})
+		if err != nil {
+			return err
+		}
+		violations = append(violations, treeViolations...)
+
+		// Codes can only be checked once the rewrite rules are valid
+		if len(ruleViolations) == 0 {
+			codeViolations, err := cloneCodeViolations(ctx, repo, source, rewrite)
+			if err != nil {
+				return err
+			}
+			violations = append(violations, codeViolations...)
+		}
+		if len(violations) > 0 {
+			return domain.NewValidationError(violations)
+		}
+
+		rewriteCodes(&source, rewrite)
+		clone, err = createTree(ctx, repo, source, req.ParentID, &events)
+		return err
+	})
+	if err != nil {
+		return nil, mutationError("clone menu", err)
+	}
+
+	for _, event := range events {
+		s.publish(event)
+	}
+
+	return clone, nil
+}
+
+// codeRewriter returns the function that derives the code of a copy from the
+// code of its source, along with any problems in the rewrite rules of req
+func codeRewriter(req *domain.CloneMenuRequest) (func(string) string, []domain.FieldViolation) {
+	var violations []domain.FieldViolation
+	if req.CodePrefix == "" && req.CodeSuffix == "" && req.CodePattern == "" {
+		violations = append(violations, domain.FieldViolation{
+			Field:   "code_prefix",
+			Message: "a code prefix, suffix or pattern is required",
+		})
+	}
+	if req.CodeReplacement != "" && req.CodePattern == "" {
+		violations = append(violations, domain.FieldViolation{
+			Field:   "code_replacement",
+			Message: "requires code_pattern",
+		})
+	}
+
+	var pattern *regexp.Regexp
+	if req.CodePattern != "" {
+		var err error
+		pattern, err = regexp.Compile(req.CodePattern)
+		if err != nil {
+			violations = append(violations, domain.FieldViolation{
+				Field:   "code_pattern",
+				Message: fmt.Sprintf("is not a valid regular expression: %v", err),
+			})
+		}
+	}
+
+	return func(code string) string {
+		if pattern != nil {
+			code = pattern.ReplaceAllString(code, req.CodeReplacement)
+		}
+		return req.CodePrefix + code + req.CodeSuffix
+	}, violations
+}
+
+// cloneCodeViolations reports every rewritten code in the tree of source that
+// is invalid, shared by two copies or already used by a menu
+func cloneCodeViolations(ctx context.Context, repo domain.MenuRepository, source domain.Menu, rewrite func(string) string) ([]domain.FieldViolation, error) {
+	var from, to []string
+	var collect func(menu domain.Menu)
+	collect = func(menu domain.Menu) {
+		from = append(from, menu.Code)
+		to = append(to, rewrite(menu.Code))
+		for _, child := range menu.Children {
+			collect(child)
+		}
+	}
+	collect(source)
+
+	conflicts, err := codeConflicts(ctx, repo, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []domain.FieldViolation
+	for i, code := range to {
+		problem := conflicts[i]
+		switch {
+		case !menuCodePattern.MatchString(code):
+			problem = menuCodeMessage
+		case len(code) > maxCodeLength:
+			problem = fmt.Sprintf("must be at most %d characters", maxCodeLength)
+		}
+
+		if problem != "" {
+			violations = append(violations, domain.FieldViolation{
+				Field:   "code",
+				Message: fmt.Sprintf("copy of %q: code %q %s", from[i], code, problem),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// rewriteCodes rewrites the code of menu and of all its descendants
+func rewriteCodes(menu *domain.Menu, rewrite func(string) string) {
+	menu.Code = rewrite(menu.Code)
+	for i := range menu.Children {
+		rewriteCodes(&menu.Children[i], rewrite)
+	}
+}
@@ -1,31 +1,36 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"stk-technical-test-api/internal/domain"
 )
 
+// maxSearchLimit caps the number of menus returned by a search
+const maxSearchLimit = 100
+
 type menuService struct {
-	repo domain.MenuRepository
+	repo      domain.MenuRepository
+	publisher domain.MenuEventPublisher
+	validator *menuValidator
 }
 
 // NewMenuService creates a new menu service instance
-func NewMenuService(repo domain.MenuRepository) domain.MenuService {
+func NewMenuService(repo domain.MenuRepository, publisher domain.MenuEventPublisher, limits domain.MenuLimits) domain.MenuService {
 	return &menuService{
-		repo: repo,
+		repo:      repo,
+		publisher: publisher,
+		validator: newMenuValidator(limits),
 	}
 }
 
-func (s *menuService) CreateMenu(req *domain.CreateMenuRequest) (*domain.Menu, error) {
-	// Validate parent exists if provided
-	if req.ParentID != nil {
-		_, err := s.repo.FindByID(*req.ParentID)
-		if err != nil {
-			return nil, fmt.Errorf("parent menu not found")
-		}
-	}
+func (s *menuService) CreateMenu(ctx context.Context, req *domain.CreateMenuRequest) (*domain.Menu, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Code = strings.TrimSpace(req.Code)
 
 	menu := &domain.Menu{
 		ParentID:    req.ParentID,
@@ -40,145 +45,439 @@ func (s *menuService) CreateMenu(req *domain.CreateMenuRequest) (*domain.Menu, e
 		UpdatedAt:   time.Now(),
 	}
 
-	err := s.repo.Create(menu)
+	var event domain.MenuEvent
+	err := s.repo.WithTx(ctx, func(repo domain.MenuRepository) error {
+		// Lock the parent so it cannot be deleted or filled up concurrently
+		if err := lockParent(ctx, repo, req.ParentID); err != nil {
+			return err
+		}
+
+		err := s.validator.validateMenu(ctx, repo, req, menuPlacement{
+			parentID: req.ParentID,
+			name:     req.Name,
+			moved:    true,
+			renamed:  true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := repo.Create(ctx, menu); err != nil {
+			return err
+		}
+		event = newMenuEvent(domain.MenuEventCreated, menu.ID, menu.ParentID)
+		return repo.EnqueueEvent(ctx, newOutboxEvent(event))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create menu: %w", err)
+		return nil, mutationError("create menu", err)
 	}
 
+	s.publish(event)
+
 	return menu, nil
 }
 
-func (s *menuService) UpdateMenu(id int64, req *domain.UpdateMenuRequest) (*domain.Menu, error) {
-	// Check if menu exists
-	menu, err := s.repo.FindByID(id)
-	if err != nil {
-		return nil, fmt.Errorf("menu not found")
-	}
+func (s *menuService) UpdateMenu(ctx context.Context, id int64, req *domain.UpdateMenuRequest) (*domain.Menu, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Code = strings.TrimSpace(req.Code)
 
-	// Validate parent exists if provided
-	if req.ParentID != nil {
-		// Check if trying to set itself as parent
-		if *req.ParentID == id {
-			return nil, fmt.Errorf("menu cannot be its own parent")
+	var (
+		menu  *domain.Menu
+		event domain.MenuEvent
+	)
+	err := s.repo.WithTx(ctx, func(repo domain.MenuRepository) error {
+		// Lock the menu first, then its new parent
+		var err error
+		menu, err = repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
 		}
 
-		_, err := s.repo.FindByID(*req.ParentID)
+		moved := !sameParent(menu.ParentID, req.ParentID)
+		if moved {
+			if err := lockParent(ctx, repo, req.ParentID); err != nil {
+				return err
+			}
+		}
+
+		// Validate parent does not create a cycle
+		if req.ParentID != nil {
+			if err := checkParent(ctx, repo, id, *req.ParentID); err != nil {
+				return err
+			}
+		}
+
+		err = s.validator.validateMenu(ctx, repo, req, menuPlacement{
+			id:       id,
+			parentID: req.ParentID,
+			name:     req.Name,
+			moved:    moved,
+			renamed:  !strings.EqualFold(menu.Name, req.Name),
+		})
 		if err != nil {
-			return nil, fmt.Errorf("parent menu not found")
+			return err
 		}
-	}
 
-	// Update fields
-	menu.ParentID = req.ParentID
-	menu.Name = req.Name
-	menu.Code = req.Code
-	menu.Description = req.Description
-	menu.Route = req.Route
-	menu.Icon = req.Icon
-	menu.OrderIndex = req.OrderIndex
-	menu.IsActive = req.IsActive
-	menu.UpdatedAt = time.Now()
+		eventType := updateEventType(menu, req)
+		oldParentID := menu.ParentID
 
-	err = s.repo.Update(menu)
+		// Update fields
+		menu.ParentID = req.ParentID
+		menu.Name = req.Name
+		menu.Code = req.Code
+		menu.Description = req.Description
+		menu.Route = req.Route
+		menu.Icon = req.Icon
+		menu.OrderIndex = req.OrderIndex
+		menu.IsActive = req.IsActive
+		menu.UpdatedAt = time.Now()
+
+		if err := repo.Update(ctx, menu); err != nil {
+			return err
+		}
+		if eventType == domain.MenuEventMoved {
+			event = newMenuEvent(eventType, menu.ID, oldParentID, menu.ParentID)
+		} else {
+			event = newMenuEvent(eventType, menu.ID, menu.ParentID)
+		}
+		return repo.EnqueueEvent(ctx, newOutboxEvent(event))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update menu: %w", err)
+		return nil, mutationError("update menu", err)
 	}
 
+	s.publish(event)
+
 	return menu, nil
 }
 
-func (s *menuService) DeleteMenu(id int64) error {
-	// Check if menu exists
-	_, err := s.repo.FindByID(id)
-	if err != nil {
-		return fmt.Errorf("menu not found")
-	}
+func (s *menuService) DeleteMenu(ctx context.Context, id int64) error {
+	var event domain.MenuEvent
+	err := s.repo.WithTx(ctx, func(repo domain.MenuRepository) error {
+		// Holding the lock keeps children from being attached before the delete
+		menu, err := repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	err = s.repo.Delete(id)
+		if err := repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		event = newMenuEvent(domain.MenuEventDeleted, id, menu.ParentID)
+		return repo.EnqueueEvent(ctx, newOutboxEvent(event))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete menu: %w", err)
+		return mutationError("delete menu", err)
 	}
 
+	s.publish(event)
+
 	return nil
 }
 
-func (s *menuService) GetMenuByID(id int64) (*domain.Menu, error) {
-	menu, err := s.repo.FindByID(id)
+func (s *menuService) GetMenuByID(ctx context.Context, id int64) (*domain.Menu, error) {
+	menu, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("menu not found")
+		return nil, err
 	}
 	return menu, nil
 }
 
-func (s *menuService) GetMenuByUUID(uuid string) (*domain.Menu, error) {
-	menu, err := s.repo.FindByUUID(uuid)
+func (s *menuService) GetMenuByUUID(ctx context.Context, uuid string) (*domain.Menu, error) {
+	menu, err := s.repo.FindByUUID(ctx, uuid)
 	if err != nil {
-		return nil, fmt.Errorf("menu not found")
+		return nil, err
 	}
 	return menu, nil
 }
 
-func (s *menuService) GetAllMenus() ([]domain.Menu, error) {
-	menus, err := s.repo.FindAll()
+func (s *menuService) GetAllMenus(ctx context.Context) ([]domain.Menu, error) {
+	menus, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get menus: %w", err)
 	}
 	return menus, nil
 }
 
-func (s *menuService) GetRootMenus() ([]domain.Menu, error) {
-	menus, err := s.repo.FindRootMenus()
+func (s *menuService) GetRootMenus(ctx context.Context) ([]domain.Menu, error) {
+	menus, err := s.repo.FindRootMenus(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get root menus: %w", err)
 	}
 	return menus, nil
 }
 
-func (s *menuService) GetMenuHierarchy() ([]domain.Menu, error) {
-	menus, err := s.repo.FindHierarchical()
+func (s *menuService) GetMenuHierarchy(ctx context.Context) ([]domain.Menu, error) {
+	menus, err := s.repo.FindHierarchical(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get menu hierarchy: %w", err)
 	}
 	return menus, nil
 }
 
-func (s *menuService) GetHierarchyByRootID(rootID int64) ([]domain.Menu, error) {
+func (s *menuService) GetHierarchyByRootID(ctx context.Context, rootID int64) ([]domain.Menu, error) {
 	// Check if menu exists and is a root menu
-	menu, err := s.repo.FindByID(rootID)
+	menu, err := s.repo.FindByID(ctx, rootID)
 	if err != nil {
-		return nil, fmt.Errorf("root menu not found")
+		return nil, err
 	}
 
 	if menu.ParentID != nil {
-		return nil, fmt.Errorf("menu is not a root menu")
+		return nil, domain.NewError(domain.ErrValidation, "menu is not a root menu")
 	}
 
-	menus, err := s.repo.FindHierarchicalByRootID(rootID)
+	menus, err := s.repo.FindHierarchicalByRootID(ctx, rootID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get menu hierarchy: %w", err)
 	}
 	return menus, nil
 }
 
-func (s *menuService) GetMenuDetail(id int64) (*domain.MenuDetail, error) {
-	detail, err := s.repo.FindDetailByID(id)
+func (s *menuService) GetMenuDetail(ctx context.Context, id int64) (*domain.MenuDetail, error) {
+	detail, err := s.repo.FindDetailByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("menu not found")
+		return nil, err
 	}
 	return detail, nil
 }
 
-func (s *menuService) GetChildrenByParentID(parentID int64) ([]domain.Menu, error) {
+func (s *menuService) GetChildrenByParentID(ctx context.Context, parentID int64) ([]domain.Menu, error) {
 	// Validate parent exists
-	_, err := s.repo.FindByID(parentID)
+	_, err := s.repo.FindByID(ctx, parentID)
 	if err != nil {
-		return nil, fmt.Errorf("parent menu not found")
+		return nil, err
 	}
 
-	menus, err := s.repo.FindChildrenByParentID(parentID)
+	menus, err := s.repo.FindChildrenByParentID(ctx, parentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get children: %w", err)
 	}
 	return menus, nil
 }
 
+func (s *menuService) GetChildrenByParentIDs(ctx context.Context, parentIDs []int64) (map[int64][]domain.Menu, error) {
+	menus, err := s.repo.FindChildrenByParentIDs(ctx, parentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get children: %w", err)
+	}
+
+	// Group children by parent, keeping the repository ordering
+	children := make(map[int64][]domain.Menu, len(parentIDs))
+	for _, menu := range menus {
+		children[*menu.ParentID] = append(children[*menu.ParentID], menu)
+	}
+	return children, nil
+}
+
+func (s *menuService) GetMenusByIDs(ctx context.Context, ids []int64) (map[int64]domain.Menu, error) {
+	menus, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menus: %w", err)
+	}
+
+	byID := make(map[int64]domain.Menu, len(menus))
+	for _, menu := range menus {
+		byID[menu.ID] = menu
+	}
+	return byID, nil
+}
+
+func (s *menuService) SearchMenus(ctx context.Context, query string, limit int) ([]domain.Menu, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, domain.NewError(domain.ErrValidation, "search query is required")
+	}
+
+	if limit <= 0 || limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	menus, err := s.repo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search menus: %w", err)
+	}
+	return menus, nil
+}
+
+// checkParent verifies that parentID is neither the menu itself nor one of
+// its descendants. A missing parent is reported by the validator.
+func checkParent(ctx context.Context, repo domain.MenuRepository, id, parentID int64) error {
+	if parentID == id {
+		return domain.NewError(domain.ErrCycle, "menu cannot be its own parent")
+	}
+
+	// Walk up from the new parent; reaching the menu means a cycle
+	for current := &parentID; current != nil; {
+		parent, err := repo.FindByID(ctx, *current)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if parent.ID == id {
+			return domain.NewError(domain.ErrCycle, "menu cannot be moved under its own descendant")
+		}
+		current = parent.ParentID
+	}
+
+	return nil
+}
+
+// lockParent locks the parent row for the rest of the transaction. A missing
+// parent is reported by the validator. Roots have no row to lock, so the
+// children limit and sibling name rules are not enforced between concurrent
+// writes of root menus.
+func lockParent(ctx context.Context, repo domain.MenuRepository, parentID *int64) error {
+	if parentID == nil {
+		return nil
+	}
+
+	_, err := repo.FindByIDForUpdate(ctx, *parentID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// createTree creates menu under parentID followed by its descendants, and
+// returns the created menu with its children. The events of the new menus
+// are enqueued and appended to events.
+func createTree(ctx context.Context, repo domain.MenuRepository, menu domain.Menu, parentID *int64, events *[]domain.MenuEvent) (*domain.Menu, error) {
+	created := &domain.Menu{
+		ParentID:    parentID,
+		Name:        menu.Name,
+		Code:        menu.Code,
+		Description: menu.Description,
+		Route:       menu.Route,
+		Icon:        menu.Icon,
+		OrderIndex:  menu.OrderIndex,
+		IsActive:    menu.IsActive,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := repo.Create(ctx, created); err != nil {
+		return nil, err
+	}
+
+	event := newMenuEvent(domain.MenuEventCreated, created.ID, created.ParentID)
+	if err := repo.EnqueueEvent(ctx, newOutboxEvent(event)); err != nil {
+		return nil, err
+	}
+	*events = append(*events, event)
+
+	for _, child := range menu.Children {
+		createdChild, err := createTree(ctx, repo, child, &created.ID, events)
+		if err != nil {
+			return nil, err
+		}
+		created.Children = append(created.Children, *createdChild)
+	}
+	return created, nil
+}
+
+// codeConflicts tells for each of codes why no new menu can have it: it is
+// already taken, or it appeared earlier in codes. Usable codes get "".
+func codeConflicts(ctx context.Context, repo domain.MenuRepository, codes []string) ([]string, error) {
+	existing, err := repo.FindByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, menu := range existing {
+		taken[menu.Code] = true
+	}
+
+	conflicts := make([]string, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for i, code := range codes {
+		switch {
+		case taken[code]:
+			conflicts[i] = "already exists"
+		case seen[code]:
+			conflicts[i] = "is used more than once"
+		}
+		seen[code] = true
+	}
+	return conflicts, nil
+}
+
+// mutationError keeps domain errors as they are so their message reaches the
+// client, and adds context to anything else
+func mutationError(action string, err error) error {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return err
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
+// newMenuEvent builds a menu event. The affected IDs always start with the
+// menu itself, followed by any non-nil parent IDs touched by the change.
+func newMenuEvent(eventType domain.MenuEventType, menuID int64, parentIDs ...*int64) domain.MenuEvent {
+	affected := []int64{menuID}
+	for _, parentID := range parentIDs {
+		if parentID != nil {
+			affected = append(affected, *parentID)
+		}
+	}
+
+	return domain.MenuEvent{
+		Type:        eventType,
+		MenuID:      menuID,
+		AffectedIDs: affected,
+		OccurredAt:  time.Now(),
+	}
+}
+
+// newOutboxEvent converts a menu event into its outbox record
+func newOutboxEvent(event domain.MenuEvent) *domain.OutboxEvent {
+	return &domain.OutboxEvent{
+		EventType:   event.Type,
+		MenuID:      event.MenuID,
+		AffectedIDs: event.AffectedIDs,
+		OccurredAt:  event.OccurredAt,
+	}
+}
+
+// publish broadcasts a committed menu event to live subscribers
+func (s *menuService) publish(event domain.MenuEvent) {
+	if s.publisher == nil {
+		return
+	}
+	s.publisher.Publish(event)
+}
+
+// updateEventType classifies an update: a parent change is a move, a change
+// limited to order_index is a reorder, anything else is a plain update
+func updateEventType(menu *domain.Menu, req *domain.UpdateMenuRequest) domain.MenuEventType {
+	if !sameParent(menu.ParentID, req.ParentID) {
+		return domain.MenuEventMoved
+	}
+
+	if menu.OrderIndex != req.OrderIndex &&
+		menu.Name == req.Name &&
+		menu.Code == req.Code &&
+		sameString(menu.Description, req.Description) &&
+		sameString(menu.Route, req.Route) &&
+		sameString(menu.Icon, req.Icon) &&
+		menu.IsActive == req.IsActive {
+		return domain.MenuEventReordered
+	}
+
+	return domain.MenuEventUpdated
+}
+
+func sameParent(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
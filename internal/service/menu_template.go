@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// templatePlaceholder matches a {{variable}} placeholder and captures the
+// variable name
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+
+// templateField is a field of a template menu that may contain placeholders
+type templateField struct {
+	name  string
+	value string
+}
+
+// templateFields returns the fields of node that may contain placeholders
+func templateFields(node domain.MenuTemplateNode) []templateField {
+	fields := []templateField{{"name", node.Name}, {"code", node.Code}}
+	if node.Route != nil {
+		fields = append(fields, templateField{"route", *node.Route})
+	}
+	return fields
+}
+
+// walkTemplate calls fn for node and each of its descendants along with
+// their field paths, e.g. "tree.children[0]"
+func walkTemplate(node domain.MenuTemplateNode, path string, fn func(node domain.MenuTemplateNode, path string)) {
+	fn(node, path)
+	for i, child := range node.Children {
+		walkTemplate(child, fmt.Sprintf("%s.children[%d]", path, i), fn)
+	}
+}
+
+// templateVariables returns the sorted names of the variables used in tree
+func templateVariables(tree domain.MenuTemplateNode) []string {
+	variables := []string{}
+	walkTemplate(tree, "tree", func(node domain.MenuTemplateNode, _ string) {
+		for _, field := range templateFields(node) {
+			for _, match := range templatePlaceholder.FindAllStringSubmatch(field.value, -1) {
+				if !slices.Contains(variables, match[1]) {
+					variables = append(variables, match[1])
+				}
+			}
+		}
+	})
+	slices.Sort(variables)
+	return variables
+}
+
+// fillPlaceholders replaces the placeholders in s with their values
+func fillPlaceholders(s string, values map[string]string) string {
+	return templatePlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+		return values[templatePlaceholder.FindStringSubmatch(placeholder)[1]]
+	})
+}
+
+// renderTemplate turns a template tree into the menus it describes
+func renderTemplate(node domain.MenuTemplateNode, values map[string]string) domain.Menu {
+	menu := domain.Menu{
+		Name:        strings.TrimSpace(fillPlaceholders(node.Name, values)),
+		Code:        strings.TrimSpace(fillPlaceholders(node.Code, values)),
+		Description: node.Description,
+		Icon:        node.Icon,
+		OrderIndex:  node.OrderIndex,
+		IsActive:    node.IsActive,
+	}
+	if node.Route != nil {
+		route := fillPlaceholders(*node.Route, values)
+		menu.Route = &route
+	}
+	for _, child := range node.Children {
+		menu.Children = append(menu.Children, renderTemplate(child, values))
+	}
+	return menu
+}
+
+// InstantiateTemplate creates the menus of tmpl under req.ParentID in one
+// transaction. Like a clone, every problem of the rendered menus is reported
+// before anything is written.
+func (s *menuService) InstantiateTemplate(ctx context.Context, tmpl *domain.MenuTemplate, req *domain.InstantiateMenuTemplateRequest) (*domain.Menu, error) {
+	if violations := variableViolations(tmpl.Tree, req.Variables); len(violations) > 0 {
+		return nil, domain.NewValidationError(violations)
+	}
+	tree := renderTemplate(tmpl.Tree, req.Variables)
+
+	var (
+		root   *domain.Menu
+		events []domain.MenuEvent
+	)
+	err := s.repo.WithTx(ctx, func(repo domain.MenuRepository) error {
+		// Lock the parent so it cannot be deleted or filled up concurrently
+		if err := lockParent(ctx, repo, req.ParentID); err != nil {
+			return err
+		}
+
+		violations, err := s.validator.treeViolations(ctx, repo, menuPlacement{
+			parentID: req.ParentID,
+			name:     tree.Name,
+			moved:    true,
+			renamed:  true,
+			height:   subtreeHeight([]domain.Menu{tree}),
+		})
+		if err != nil {
+			return err
+		}
+		for i := range violations {
+			if violations[i].Field == "name" {
+				violations[i].Field = "tree.name"
+			}
+		}
+
+		renderedViolations, err := s.validator.renderedViolations(ctx, repo, tree)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, renderedViolations...)
+		if len(violations) > 0 {
+			return domain.NewValidationError(violations)
+		}
+
+		root, err = createTree(ctx, repo, tree, req.ParentID, &events)
+		return err
+	})
+	if err != nil {
+		return nil, mutationError("instantiate menu template", err)
+	}
+
+	for _, event := range events {
+		s.publish(event)
+	}
+
+	return root, nil
+}
+
+// variableViolations reports the variables of tree without a value and the
+// values of variables tree does not use
+func variableViolations(tree domain.MenuTemplateNode, values map[string]string) []domain.FieldViolation {
+	var violations []domain.FieldViolation
+	used := templateVariables(tree)
+	for _, name := range used {
+		if values[name] == "" {
+			violations = append(violations, domain.FieldViolation{
+				Field:   "variables." + name,
+				Message: "is required",
+			})
+		}
+	}
+
+	var unused []string
+	for name := range values {
+		if !slices.Contains(used, name) {
+			unused = append(unused, name)
+		}
+	}
+	slices.Sort(unused)
+	for _, name := range unused {
+		violations = append(violations, domain.FieldViolation{
+			Field:   "variables." + name,
+			Message: "is not used by the template",
+		})
+	}
+	return violations
+}
+
+// renderedViolations checks every menu rendered from a template against the
+// rules of a created menu, and reports codes that are repeated or taken
+func (v *menuValidator) renderedViolations(ctx context.Context, repo domain.MenuRepository, tree domain.Menu) ([]domain.FieldViolation, error) {
+	var (
+		violations []domain.FieldViolation
+		codes      []string
+		codePaths  []string
+	)
+
+	var check func(menu domain.Menu, path string)
+	check = func(menu domain.Menu, path string) {
+		for _, violation := range v.fieldViolations(&domain.CreateMenuRequest{
+			Name:        menu.Name,
+			Code:        menu.Code,
+			Description: menu.Description,
+			Route:       menu.Route,
+			Icon:        menu.Icon,
+			OrderIndex:  menu.OrderIndex,
+			IsActive:    menu.IsActive,
+		}) {
+			violation.Field = path + "." + violation.Field
+			violations = append(violations, violation)
+		}
+		codes = append(codes, menu.Code)
+		codePaths = append(codePaths, path+".code")
+
+		if v.limits.MaxChildren > 0 && len(menu.Children) > v.limits.MaxChildren {
+			violations = append(violations, domain.FieldViolation{
+				Field:   path + ".children",
+				Message: fmt.Sprintf("menu cannot have more than %d children", v.limits.MaxChildren),
+			})
+		}
+
+		for i, child := range menu.Children {
+			childPath := fmt.Sprintf("%s.children[%d]", path, i)
+			for _, sibling := range menu.Children[:i] {
+				if strings.EqualFold(sibling.Name, child.Name) {
+					violations = append(violations, domain.FieldViolation{
+						Field:   childPath + ".name",
+						Message: "a menu with the same name already exists under this parent",
+					})
+					break
+				}
+			}
+			check(child, childPath)
+		}
+	}
+	check(tree, "tree")
+
+	conflicts, err := codeConflicts(ctx, repo, codes)
+	if err != nil {
+		return nil, err
+	}
+	for i, problem := range conflicts {
+		if problem != "" {
+			violations = append(violations, domain.FieldViolation{
+				Field:   codePaths[i],
+				Message: fmt.Sprintf("code %q %s", codes[i], problem),
+			})
+		}
+	}
+	return violations, nil
+}
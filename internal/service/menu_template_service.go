@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+type menuTemplateService struct {
+	repo      domain.MenuTemplateRepository
+	menus     domain.MenuService
+	validator *menuValidator
+}
+
+// NewMenuTemplateService creates a new menu template service instance.
+// Templates are instantiated through menus.
+func NewMenuTemplateService(repo domain.MenuTemplateRepository, menus domain.MenuService, limits domain.MenuLimits) domain.MenuTemplateService {
+	return &menuTemplateService{
+		repo:      repo,
+		menus:     menus,
+		validator: newMenuValidator(limits),
+	}
+}
+
+func (s *menuTemplateService) CreateTemplate(ctx context.Context, req *domain.CreateMenuTemplateRequest) (*domain.MenuTemplate, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	if err := s.validateTemplate(req, req.Tree); err != nil {
+		return nil, err
+	}
+
+	tmpl := &domain.MenuTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		Tree:        req.Tree,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, tmpl); err != nil {
+		return nil, mutationError("create menu template", err)
+	}
+
+	return withVariables(tmpl), nil
+}
+
+func (s *menuTemplateService) UpdateTemplate(ctx context.Context, name string, req *domain.UpdateMenuTemplateRequest) (*domain.MenuTemplate, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	tmpl, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTemplate(req, req.Tree); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	tmpl.Name = req.Name
+	tmpl.Description = req.Description
+	tmpl.Tree = req.Tree
+	tmpl.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, tmpl); err != nil {
+		return nil, mutationError("update menu template", err)
+	}
+
+	return withVariables(tmpl), nil
+}
+
+func (s *menuTemplateService) DeleteTemplate(ctx context.Context, name string) error {
+	tmpl, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, tmpl.ID); err != nil {
+		return mutationError("delete menu template", err)
+	}
+	return nil
+}
+
+func (s *menuTemplateService) GetTemplate(ctx context.Context, name string) (*domain.MenuTemplate, error) {
+	tmpl, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return withVariables(tmpl), nil
+}
+
+func (s *menuTemplateService) GetAllTemplates(ctx context.Context) ([]domain.MenuTemplate, error) {
+	templates, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get menu templates: %w", err)
+	}
+	for i := range templates {
+		withVariables(&templates[i])
+	}
+	return templates, nil
+}
+
+func (s *menuTemplateService) InstantiateTemplate(ctx context.Context, name string, req *domain.InstantiateMenuTemplateRequest) (*domain.Menu, error) {
+	tmpl, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.menus.InstantiateTemplate(ctx, tmpl, req)
+}
+
+// validateTemplate checks the fields of req and the placeholders of tree.
+// Whether the rendered menus fit into the menu tree is only known when the
+// template is instantiated.
+func (s *menuTemplateService) validateTemplate(req any, tree domain.MenuTemplateNode) error {
+	violations := s.validator.fieldViolations(req)
+
+	walkTemplate(tree, "tree", func(node domain.MenuTemplateNode, path string) {
+		for _, field := range templateFields(node) {
+			rest := templatePlaceholder.ReplaceAllString(field.value, "")
+			if strings.Contains(rest, "{{") || strings.Contains(rest, "}}") {
+				violations = append(violations, domain.FieldViolation{
+					Field:   path + "." + field.name,
+					Message: "contains an invalid placeholder, use {{variable}} with a lowercase variable name",
+				})
+				continue
+			}
+
+			// Catch codes that no variable values can make valid
+			sample := templatePlaceholder.ReplaceAllString(field.value, "x")
+			if field.name == "code" && field.value != "" && !menuCodePattern.MatchString(sample) {
+				violations = append(violations, domain.FieldViolation{
+					Field:   path + ".code",
+					Message: menuCodeMessage,
+				})
+			}
+		}
+	})
+
+	if len(violations) > 0 {
+		return domain.NewValidationError(violations)
+	}
+	return nil
+}
+
+// withVariables lists the variables of tmpl for the client
+func withVariables(tmpl *domain.MenuTemplate) *domain.MenuTemplate {
+	tmpl.Variables = templateVariables(tmpl.Tree)
+	return tmpl
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"stk-technical-test-api/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// menuCodePattern allows lowercase words separated by single dots, dashes
+// or underscores, e.g. "system.management" or "user_approval"
+var menuCodePattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+
+// menuCodeMessage explains menuCodePattern to the client
+const menuCodeMessage = "must contain only lowercase letters and digits, separated by '.', '-' or '_'"
+
+// maxCodeLength matches the max rule on the code of menu requests
+const maxCodeLength = 100
+
+// menuValidator checks menu payloads against the field rules declared in
+// their validate tags and against the tree limits
+type menuValidator struct {
+	validate *validator.Validate
+	limits   domain.MenuLimits
+}
+
+func newMenuValidator(limits domain.MenuLimits) *menuValidator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	// Report fields by their JSON names
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	validate.RegisterValidation("menucode", func(fl validator.FieldLevel) bool {
+		return menuCodePattern.MatchString(fl.Field().String())
+	})
+
+	return &menuValidator{
+		validate: validate,
+		limits:   limits,
+	}
+}
+
+// menuPlacement describes where a created or updated menu will sit in the tree
+type menuPlacement struct {
+	id       int64 // zero for a new menu
+	parentID *int64
+	name     string
+	moved    bool // parent differs from the current one
+	renamed  bool // name differs from the current one
+	height   int  // levels below a new menu that comes with descendants
+}
+
+// validateMenu collects every field and tree violation of req. Repository
+// failures are returned as is.
+func (v *menuValidator) validateMenu(ctx context.Context, repo domain.MenuRepository, req any, place menuPlacement) error {
+	violations := v.fieldViolations(req)
+
+	treeViolations, err := v.treeViolations(ctx, repo, place)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, treeViolations...)
+
+	if len(violations) > 0 {
+		return domain.NewValidationError(violations)
+	}
+	return nil
+}
+
+func (v *menuValidator) fieldViolations(req any) []domain.FieldViolation {
+	err := v.validate.Struct(req)
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return nil
+	}
+
+	violations := make([]domain.FieldViolation, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		// Drop the struct name, keeping the path of nested fields
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		violations = append(violations, domain.FieldViolation{
+			Field:   field,
+			Message: violationMessage(fe),
+		})
+	}
+	return violations
+}
+
+func (v *menuValidator) treeViolations(ctx context.Context, repo domain.MenuRepository, place menuPlacement) ([]domain.FieldViolation, error) {
+	var violations []domain.FieldViolation
+
+	level := 0
+	if place.parentID != nil {
+		parent, err := repo.FindByID(ctx, *place.parentID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return []domain.FieldViolation{{Field: "parent_id", Message: "parent menu not found"}}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		level = parent.Level + 1
+	}
+
+	if place.moved && v.limits.MaxDepth > 0 {
+		// A moved menu takes its whole subtree along
+		height := place.height
+		if place.id != 0 {
+			tree, err := repo.FindHierarchicalByRootID(ctx, place.id)
+			if err != nil {
+				return nil, err
+			}
+			height = subtreeHeight(tree)
+		}
+		if level+height >= v.limits.MaxDepth {
+			violations = append(violations, domain.FieldViolation{
+				Field:   "parent_id",
+				Message: fmt.Sprintf("menu tree cannot be deeper than %d levels", v.limits.MaxDepth),
+			})
+		}
+	}
+
+	if !place.moved && !place.renamed {
+		return violations, nil
+	}
+
+	siblings, err := repo.FindByParentID(ctx, place.parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if place.moved && v.limits.MaxChildren > 0 && len(siblings) >= v.limits.MaxChildren {
+		violations = append(violations, domain.FieldViolation{
+			Field:   "parent_id",
+			Message: fmt.Sprintf("parent menu cannot have more than %d children", v.limits.MaxChildren),
+		})
+	}
+
+	for _, sibling := range siblings {
+		if sibling.ID != place.id && strings.EqualFold(sibling.Name, place.name) {
+			violations = append(violations, domain.FieldViolation{
+				Field:   "name",
+				Message: "a menu with the same name already exists under this parent",
+			})
+			break
+		}
+	}
+
+	return violations, nil
+}
+
+// subtreeHeight returns the number of levels below the roots of tree
+func subtreeHeight(tree []domain.Menu) int {
+	height := 0
+	for _, menu := range tree {
+		if len(menu.Children) > 0 {
+			height = max(height, 1+subtreeHeight(menu.Children))
+		}
+	}
+	return height
+}
+
+func violationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		return fmt.Sprintf("must be at most %s characters", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "startswith":
+		return fmt.Sprintf("must start with %q", fe.Param())
+	case "menucode":
+		return menuCodeMessage
+	}
+	return fmt.Sprintf("failed the %s rule", fe.Tag())
+}
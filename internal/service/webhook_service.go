@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// defaultDeliveryLimit caps the delivery log returned for a subscription
+const defaultDeliveryLimit = 100
+
+var knownEventTypes = map[string]bool{
+	"*":                               true,
+	string(domain.MenuEventCreated):   true,
+	string(domain.MenuEventUpdated):   true,
+	string(domain.MenuEventMoved):     true,
+	string(domain.MenuEventDeleted):   true,
+	string(domain.MenuEventReordered): true,
+}
+
+type webhookService struct {
+	repo         domain.WebhookRepository
+	allowPrivate bool
+}
+
+// NewWebhookService creates a new webhook service instance. Unless
+// allowPrivate is set, subscription URLs may not name a loopback or
+// private address.
+func NewWebhookService(repo domain.WebhookRepository, allowPrivate bool) domain.WebhookService {
+	return &webhookService{
+		repo:         repo,
+		allowPrivate: allowPrivate,
+	}
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, req *domain.CreateWebhookRequest) (*domain.WebhookSubscription, error) {
+	if err := validateWebhook(req.URL, req.EventTypes, s.allowPrivate); err != nil {
+		return nil, err
+	}
+
+	sub := &domain.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		IsActive:   req.IsActive,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	err := s.repo.CreateSubscription(ctx, sub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (s *webhookService) UpdateSubscription(ctx context.Context, id int64, req *domain.UpdateWebhookRequest) (*domain.WebhookSubscription, error) {
+	// Check if subscription exists
+	sub, err := s.repo.FindSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhook(req.URL, req.EventTypes, s.allowPrivate); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	sub.URL = req.URL
+	if req.Secret != "" {
+		sub.Secret = req.Secret
+	}
+	sub.EventTypes = req.EventTypes
+	sub.IsActive = req.IsActive
+	sub.UpdatedAt = time.Now()
+
+	err = s.repo.UpdateSubscription(ctx, sub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	// Check if subscription exists
+	_, err := s.repo.FindSubscriptionByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteSubscription(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *webhookService) GetSubscription(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	sub, err := s.repo.FindSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *webhookService) GetAllSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subs, err := s.repo.FindAllSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	return subs, nil
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.WebhookDelivery, error) {
+	// Validate subscription exists
+	_, err := s.repo.FindSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > defaultDeliveryLimit {
+		limit = defaultDeliveryLimit
+	}
+
+	deliveries, err := s.repo.FindDeliveriesBySubscriptionID(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func validateWebhook(rawURL string, eventTypes []string, allowPrivate bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return domain.NewError(domain.ErrValidation, "webhook url must be an absolute http or https url")
+	}
+
+	// Host names are checked again when the worker connects, since they may
+	// resolve to a private address
+	if !allowPrivate && isPrivateHost(u.Hostname()) {
+		return domain.NewError(domain.ErrValidation, "webhook url must not point to a loopback or private address")
+	}
+
+	for _, t := range eventTypes {
+		if !knownEventTypes[t] {
+			return domain.NewError(domain.ErrValidation, fmt.Sprintf("unknown event type: %s", t))
+		}
+	}
+
+	return nil
+}
+
+// isPrivateHost reports whether host is localhost or a private IP address
+func isPrivateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && domain.IsPrivateAddress(ip)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+func TestValidateWebhook(t *testing.T) {
+	tests := []struct {
+		url          string
+		eventTypes   []string
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{url: "https://hooks.example.com/menus"},
+		{url: "http://203.0.113.10:8080/hook", eventTypes: []string{"*", string(domain.MenuEventMoved)}},
+		{url: "ftp://hooks.example.com", wantErr: true},
+		{url: "hooks.example.com/menus", wantErr: true},
+		{url: "https://", wantErr: true},
+		{url: "http://localhost:9000/hook", wantErr: true},
+		{url: "http://api.localhost/hook", wantErr: true},
+		{url: "http://127.0.0.1/hook", wantErr: true},
+		{url: "http://10.1.2.3/hook", wantErr: true},
+		{url: "http://192.168.0.10/hook", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "http://[::1]:8080/hook", wantErr: true},
+		{url: "http://0.0.0.0/hook", wantErr: true},
+		{url: "http://localhost:9000/hook", allowPrivate: true},
+		{url: "http://10.1.2.3/hook", allowPrivate: true},
+		{url: "https://hooks.example.com", eventTypes: []string{"menu.renamed"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateWebhook(tt.url, tt.eventTypes, tt.allowPrivate)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("validateWebhook(%q, %v, %v) = %v, want error %v", tt.url, tt.eventTypes, tt.allowPrivate, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("validateWebhook(%q) error %v is not a validation error", tt.url, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Package tlscert serves TLS certificates from disk and picks up renewed
+// certificates without a restart.
+package tlscert
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader holds the certificate pair read from CertFile and KeyFile and
+// reloads it when either file changes. A pair that fails to load is logged
+// and the previous certificate stays in use.
+type Reloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	version fileVersion
+	// failed is the version that last failed to load, so a broken pair is
+	// reported once rather than on every check
+	failed fileVersion
+}
+
+// fileVersion identifies the contents of the pair by size and modification
+// time, which also changes when a Kubernetes secret swaps its symlink
+type fileVersion struct {
+	certMod, keyMod   time.Time
+	certSize, keySize int64
+}
+
+// NewReloader loads the certificate pair, failing if it is invalid
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Watch checks the files every interval until ctx is cancelled
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.reload()
+		switch {
+		case err != nil:
+			slog.Error("Failed to reload TLS certificate, keeping the current one", "error", err)
+		case changed:
+			slog.Info("Reloaded TLS certificate", "cert_file", r.certFile)
+		}
+	}
+}
+
+// reload loads the pair if the files changed since the last load
+func (r *Reloader) reload() (bool, error) {
+	version, err := r.stat()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && (version == r.version || version == r.failed)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		r.mu.Lock()
+		r.failed = version
+		r.mu.Unlock()
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert, r.version = &cert, version
+	r.mu.Unlock()
+	return true, nil
+}
+
+func (r *Reloader) stat() (fileVersion, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fileVersion{}, fmt.Errorf("failed to read TLS certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fileVersion{}, fmt.Errorf("failed to read TLS key: %w", err)
+	}
+	return fileVersion{
+		certMod:  certInfo.ModTime(),
+		keyMod:   keyInfo.ModTime(),
+		certSize: certInfo.Size(),
+		keySize:  keyInfo.Size(),
+	}, nil
+}
+
+// ServerConfig returns a TLS configuration serving the reloader's
+// certificate over HTTP/2 and HTTP/1.1. minVersion is "1.2" or "1.3".
+func ServerConfig(r *Reloader, minVersion string) (*tls.Config, error) {
+	version, err := ParseVersion(minVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:     version,
+		GetCertificate: r.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}, nil
+}
+
+// ParseVersion converts "1.2" or "1.3" to the crypto/tls constant
+func ParseVersion(s string) (uint16, error) {
+	switch s {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q, use 1.2 or 1.3", s)
+}
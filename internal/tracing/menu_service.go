@@ -0,0 +1,159 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"stk-technical-test-api/internal/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedMenuService starts a span for every call of the wrapped service
+type tracedMenuService struct {
+	next   domain.MenuService
+	tracer trace.Tracer
+}
+
+// NewMenuService wraps service so that every call gets its own span, a child
+// of the request span and the parent of the query spans
+func NewMenuService(service domain.MenuService) domain.MenuService {
+	return &tracedMenuService{
+		next:   service,
+		tracer: otel.Tracer(instrumentationName),
+	}
+}
+
+func (s *tracedMenuService) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "MenuService."+method, trace.WithAttributes(attrs...))
+}
+
+// end records err on span, if any, and ends it. Domain errors such as not
+// found or validation failures are caused by the request, so only other
+// errors mark the span as failed.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+
+		var domainErr *domain.Error
+		if !errors.As(err, &domainErr) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+func (s *tracedMenuService) CreateMenu(ctx context.Context, req *domain.CreateMenuRequest) (*domain.Menu, error) {
+	ctx, span := s.start(ctx, "CreateMenu")
+	result, err := s.next.CreateMenu(ctx, req)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) UpdateMenu(ctx context.Context, id int64, req *domain.UpdateMenuRequest) (*domain.Menu, error) {
+	ctx, span := s.start(ctx, "UpdateMenu", attribute.Int64("menu.id", id))
+	result, err := s.next.UpdateMenu(ctx, id, req)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) CloneMenu(ctx context.Context, id int64, req *domain.CloneMenuRequest) (*domain.Menu, error) {
+	ctx, span := s.start(ctx, "CloneMenu", attribute.Int64("menu.id", id))
+	result, err := s.next.CloneMenu(ctx, id, req)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) InstantiateTemplate(ctx context.Context, tmpl *domain.MenuTemplate, req *domain.InstantiateMenuTemplateRequest) (*domain.Menu, error) {
+	ctx, span := s.start(ctx, "InstantiateTemplate", attribute.String("menu_template.name", tmpl.Name))
+	result, err := s.next.InstantiateTemplate(ctx, tmpl, req)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) DeleteMenu(ctx context.Context, id int64) error {
+	ctx, span := s.start(ctx, "DeleteMenu", attribute.Int64("menu.id", id))
+	err := s.next.DeleteMenu(ctx, id)
+	end(span, err)
+	return err
+}
+
+func (s *tracedMenuService) GetMenuByID(ctx context.Context, id int64) (*domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetMenuByID", attribute.Int64("menu.id", id))
+	result, err := s.next.GetMenuByID(ctx, id)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetMenuByUUID(ctx context.Context, uuid string) (*domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetMenuByUUID")
+	result, err := s.next.GetMenuByUUID(ctx, uuid)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetAllMenus(ctx context.Context) ([]domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetAllMenus")
+	result, err := s.next.GetAllMenus(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetRootMenus(ctx context.Context) ([]domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetRootMenus")
+	result, err := s.next.GetRootMenus(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetMenuHierarchy(ctx context.Context) ([]domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetMenuHierarchy")
+	result, err := s.next.GetMenuHierarchy(ctx)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetHierarchyByRootID(ctx context.Context, rootID int64) ([]domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetHierarchyByRootID", attribute.Int64("menu.root_id", rootID))
+	result, err := s.next.GetHierarchyByRootID(ctx, rootID)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetMenuDetail(ctx context.Context, id int64) (*domain.MenuDetail, error) {
+	ctx, span := s.start(ctx, "GetMenuDetail", attribute.Int64("menu.id", id))
+	result, err := s.next.GetMenuDetail(ctx, id)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetChildrenByParentID(ctx context.Context, parentID int64) ([]domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetChildrenByParentID", attribute.Int64("menu.parent_id", parentID))
+	result, err := s.next.GetChildrenByParentID(ctx, parentID)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetChildrenByParentIDs(ctx context.Context, parentIDs []int64) (map[int64][]domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetChildrenByParentIDs")
+	result, err := s.next.GetChildrenByParentIDs(ctx, parentIDs)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) GetMenusByIDs(ctx context.Context, ids []int64) (map[int64]domain.Menu, error) {
+	ctx, span := s.start(ctx, "GetMenusByIDs")
+	result, err := s.next.GetMenusByIDs(ctx, ids)
+	end(span, err)
+	return result, err
+}
+
+func (s *tracedMenuService) SearchMenus(ctx context.Context, query string, limit int) ([]domain.Menu, error) {
+	ctx, span := s.start(ctx, "SearchMenus", attribute.Int("menu.search.limit", limit))
+	result, err := s.next.SearchMenus(ctx, query, limit)
+	end(span, err)
+	return result, err
+}
@@ -0,0 +1,133 @@
+// Package tracing configures OpenTelemetry and traces the menu service
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"stk-technical-test-api/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans created by this package
+const instrumentationName = "stk-technical-test-api/internal/tracing"
+
+// Setup installs the global tracer provider and the W3C trace-context and
+// baggage propagators. The returned function flushes pending spans and
+// stops the exporter; it must be called on shutdown.
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closeOutput, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(attribute.String("service.name", cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Follow the caller's sampling decision, sample new traces by ratio
+		sdktrace.WithSampler(sdktrace.ParentBased(rootSampler{
+			ratio: sdktrace.TraceIDRatioBased(cfg.SampleRatio),
+		})),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closeOutput())
+	}, nil
+}
+
+// newExporter creates the span exporter named by cfg.Exporter. The returned
+// function closes the output file of the stdout exporter.
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+
+	switch cfg.Exporter {
+	case config.TracingOTLP:
+		var (
+			exporter sdktrace.SpanExporter
+			err      error
+		)
+		switch cfg.OTLPProtocol {
+		case "grpc":
+			exporter, err = otlptracegrpc.New(ctx)
+		case "http":
+			exporter, err = otlptracehttp.New(ctx)
+		default:
+			return nil, nil, fmt.Errorf("unsupported OTLP protocol %q, use grpc or http", cfg.OTLPProtocol)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		return exporter, noClose, nil
+
+	case config.TracingStdout:
+		var w io.Writer = os.Stdout
+		closeOutput := noClose
+		if cfg.File != "" {
+			f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to open trace file: %w", err)
+			}
+			w, closeOutput = f, f.Close
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, nil, err
+		}
+		return exporter, closeOutput, nil
+	}
+
+	return nil, nil, fmt.Errorf("unsupported tracing exporter %q, use none, otlp or stdout", cfg.Exporter)
+}
+
+// rootSampler decides on spans that start a new trace. Client spans without
+// a parent, such as the queries of the webhook worker polling the outbox,
+// are dropped so they do not flood the backend with one-span traces.
+type rootSampler struct {
+	ratio sdktrace.Sampler
+}
+
+func (s rootSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if p.Kind == trace.SpanKindClient {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.Drop,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.ratio.ShouldSample(p)
+}
+
+func (s rootSampler) Description() string {
+	return "RootSampler{" + s.ratio.Description() + "}"
+}
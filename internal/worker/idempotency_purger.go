@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// IdempotencyPurger deletes expired idempotency keys. Expired keys are
+// already ignored when a request reuses them; purging keeps the table small.
+type IdempotencyPurger struct {
+	repo     domain.IdempotencyRepository
+	interval time.Duration
+}
+
+// NewIdempotencyPurger creates a purger running every interval
+func NewIdempotencyPurger(repo domain.IdempotencyRepository, interval time.Duration) *IdempotencyPurger {
+	return &IdempotencyPurger{
+		repo:     repo,
+		interval: interval,
+	}
+}
+
+// Run purges expired keys every interval until ctx is cancelled
+func (p *IdempotencyPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := p.repo.DeleteExpired(ctx, time.Now())
+		switch {
+		case err != nil:
+			slog.ErrorContext(ctx, "Failed to purge idempotency keys", "error", err)
+		case deleted > 0:
+			slog.DebugContext(ctx, "Purged idempotency keys", "count", deleted)
+		}
+	}
+}
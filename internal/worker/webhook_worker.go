@@ -0,0 +1,261 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"stk-technical-test-api/internal/domain"
+)
+
+// Webhook request headers
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxErrorBody limits how much of a failed response is kept in the delivery log
+const maxErrorBody = 1024
+
+// purgeInterval is how often delivered outbox events past their retention
+// are deleted
+const purgeInterval = time.Hour
+
+// WebhookWorkerConfig holds the delivery settings of the webhook worker
+type WebhookWorkerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+	// Retention is how long dispatched outbox events and their finished
+	// deliveries are kept; zero keeps them forever
+	Retention time.Duration
+	// AllowPrivateURLs permits connections to loopback and private addresses
+	AllowPrivateURLs bool
+}
+
+// WebhookWorker moves outbox events into deliveries and sends them to
+// subscribers with HMAC signatures and exponential backoff retries
+type WebhookWorker struct {
+	repo       domain.WebhookRepository
+	client     *http.Client
+	cfg        WebhookWorkerConfig
+	now        func() time.Time
+	lastPurged time.Time
+}
+
+// NewWebhookWorker creates a new webhook worker. A nil client uses a
+// client with the configured timeout that, unless AllowPrivateURLs is set,
+// refuses to connect to loopback and private addresses.
+func NewWebhookWorker(repo domain.WebhookRepository, client *http.Client, cfg WebhookWorkerConfig) *WebhookWorker {
+	if client == nil {
+		client = newClient(cfg)
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+
+	return &WebhookWorker{
+		repo:   repo,
+		client: client,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+// Run processes the outbox every poll interval until ctx is cancelled
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.ProcessOnce(ctx); err != nil {
+			slog.ErrorContext(ctx, "Webhook worker error", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessOnce dispatches pending outbox events, sends every due delivery
+// and, once per purge interval, deletes delivered events past retention
+func (w *WebhookWorker) ProcessOnce(ctx context.Context) error {
+	if _, err := w.repo.DispatchPendingEvents(ctx, w.cfg.BatchSize, w.now()); err != nil {
+		return fmt.Errorf("failed to dispatch outbox events: %w", err)
+	}
+
+	// Lease claimed deliveries a little longer than a request may take
+	lease := w.client.Timeout + time.Minute
+
+	deliveries, err := w.repo.ClaimDueDeliveries(ctx, w.cfg.BatchSize, w.now(), lease)
+	if err != nil {
+		return fmt.Errorf("failed to claim deliveries: %w", err)
+	}
+
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			return nil
+		}
+		w.deliver(ctx, &deliveries[i])
+		if err := w.repo.UpdateDelivery(ctx, &deliveries[i]); err != nil {
+			return fmt.Errorf("failed to update delivery: %w", err)
+		}
+	}
+
+	return w.purge(ctx)
+}
+
+// purge deletes the outbox events dispatched more than Retention ago
+func (w *WebhookWorker) purge(ctx context.Context) error {
+	now := w.now()
+	if w.cfg.Retention <= 0 || now.Sub(w.lastPurged) < purgeInterval {
+		return nil
+	}
+
+	purged, err := w.repo.PurgeDispatchedEvents(ctx, now.Add(-w.cfg.Retention))
+	if err != nil {
+		return fmt.Errorf("failed to purge outbox events: %w", err)
+	}
+	w.lastPurged = now
+	if purged > 0 {
+		slog.InfoContext(ctx, "Purged delivered outbox events", "count", purged)
+	}
+	return nil
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, d *domain.WebhookDelivery) {
+	if !d.Subscription.IsActive {
+		msg := "subscription is inactive"
+		d.Status = domain.DeliveryStatusFailed
+		d.LastError = &msg
+		return
+	}
+
+	d.Attempts++
+
+	statusCode, err := w.send(ctx, d)
+	if statusCode != 0 {
+		d.ResponseStatus = &statusCode
+	}
+
+	now := w.now()
+	if err == nil {
+		d.Status = domain.DeliveryStatusSucceeded
+		d.DeliveredAt = &now
+		d.LastError = nil
+		return
+	}
+
+	msg := err.Error()
+	d.LastError = &msg
+
+	if d.Attempts >= w.cfg.MaxAttempts {
+		d.Status = domain.DeliveryStatusFailed
+		return
+	}
+	d.NextAttemptAt = now.Add(w.backoff(d.Attempts))
+}
+
+func (w *WebhookWorker) send(ctx context.Context, d *domain.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(d.OutboxEvent)
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(w.now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "stk-menu-webhooks/1.0")
+	req.Header.Set(HeaderEvent, string(d.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(d.Subscription.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, snippet)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// backoff returns BaseBackoff * 2^(attempts-1), capped at MaxBackoff
+func (w *WebhookWorker) backoff(attempts int) time.Duration {
+	delay := w.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if w.cfg.MaxBackoff > 0 && delay >= w.cfg.MaxBackoff {
+			return w.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// newClient creates the default delivery client
+func newClient(cfg WebhookWorkerConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateURLs {
+		// Check the resolved address, so host names pointing at internal
+		// services are refused too
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || domain.IsPrivateAddress(ip) {
+				return fmt.Errorf("refusing to connect to private address %s", host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would connect on our behalf and bypass the address check
+	transport.Proxy = nil
+
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}
+}
+
+// Sign computes the hex encoded HMAC-SHA256 of "timestamp.body" with the
+// subscription secret. Receivers recompute it to verify the sender.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
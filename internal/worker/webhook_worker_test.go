@@ -0,0 +1,261 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"stk-technical-test-api/internal/database/dbtest"
+	"stk-technical-test-api/internal/domain"
+	"stk-technical-test-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const testSecret = "0123456789abcdef"
+
+// receiver is a webhook endpoint that answers with a fixed status and
+// records every request it gets
+type receiver struct {
+	t      *testing.T
+	server *httptest.Server
+	status int
+
+	mu       sync.Mutex
+	requests int
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	rc := &receiver{t: t, status: status}
+	rc.server = httptest.NewServer(http.HandlerFunc(rc.serve))
+	t.Cleanup(rc.server.Close)
+	return rc
+}
+
+func (rc *receiver) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.t.Error(err)
+	}
+
+	want := "sha256=" + Sign(testSecret, r.Header.Get(HeaderTimestamp), body)
+	if got := r.Header.Get(HeaderSignature); got != want {
+		rc.t.Errorf("signature = %q, want %q", got, want)
+	}
+	if got := r.Header.Get(HeaderEvent); got != string(domain.MenuEventCreated) {
+		rc.t.Errorf("event header = %q", got)
+	}
+
+	rc.mu.Lock()
+	rc.requests++
+	rc.mu.Unlock()
+
+	w.WriteHeader(rc.status)
+	io.WriteString(w, "receiver says "+strconv.Itoa(rc.status))
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.requests
+}
+
+// workerTest runs a worker against SQLite with a clock the test moves
+type workerTest struct {
+	db     *gorm.DB
+	repo   domain.WebhookRepository
+	worker *WebhookWorker
+	now    time.Time
+	sub    *domain.WebhookSubscription
+}
+
+func newWorkerTest(t *testing.T, rc *receiver, cfg WebhookWorkerConfig) *workerTest {
+	db := dbtest.Open(t)
+	wt := &workerTest{
+		db:   db,
+		repo: repository.NewWebhookRepository(db),
+		now:  time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	wt.worker = NewWebhookWorker(wt.repo, rc.server.Client(), cfg)
+	wt.worker.now = func() time.Time { return wt.now }
+
+	wt.sub = &domain.WebhookSubscription{URL: rc.server.URL, Secret: testSecret, IsActive: true}
+	if err := wt.repo.CreateSubscription(context.Background(), wt.sub); err != nil {
+		t.Fatal(err)
+	}
+	return wt
+}
+
+// enqueue writes a menu.created event to the outbox
+func (wt *workerTest) enqueue(t *testing.T) {
+	t.Helper()
+	event := &domain.OutboxEvent{EventType: domain.MenuEventCreated, MenuID: 1, OccurredAt: wt.now}
+	if err := wt.db.Create(event).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// process runs one worker pass at the current time
+func (wt *workerTest) process(t *testing.T) {
+	t.Helper()
+	if err := wt.worker.ProcessOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// delivery returns the only delivery of the subscription
+func (wt *workerTest) delivery(t *testing.T) domain.WebhookDelivery {
+	t.Helper()
+	deliveries, err := wt.repo.FindDeliveriesBySubscriptionID(context.Background(), wt.sub.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func TestWebhookWorkerDelivers(t *testing.T) {
+	rc := newReceiver(t, http.StatusNoContent)
+	wt := newWorkerTest(t, rc, WebhookWorkerConfig{MaxAttempts: 3, BaseBackoff: time.Minute})
+	wt.enqueue(t)
+
+	wt.process(t)
+	if rc.count() != 1 {
+		t.Fatalf("receiver got %d requests, want 1", rc.count())
+	}
+
+	d := wt.delivery(t)
+	if d.Status != domain.DeliveryStatusSucceeded || d.Attempts != 1 {
+		t.Errorf("status, attempts = %s, %d, want succeeded, 1", d.Status, d.Attempts)
+	}
+	if d.ResponseStatus == nil || *d.ResponseStatus != http.StatusNoContent {
+		t.Errorf("response status = %v, want 204", d.ResponseStatus)
+	}
+	if d.DeliveredAt == nil || d.LastError != nil {
+		t.Errorf("delivered at, last error = %v, %v", d.DeliveredAt, d.LastError)
+	}
+
+	// A delivered event is not sent again
+	wt.now = wt.now.Add(time.Hour)
+	wt.process(t)
+	if rc.count() != 1 {
+		t.Errorf("receiver got %d requests after delivery, want 1", rc.count())
+	}
+}
+
+func TestWebhookWorkerRetriesWithBackoff(t *testing.T) {
+	rc := newReceiver(t, http.StatusInternalServerError)
+	wt := newWorkerTest(t, rc, WebhookWorkerConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  90 * time.Second,
+	})
+	wt.enqueue(t)
+
+	steps := []struct {
+		wait       time.Duration
+		wantStatus string
+		wantNext   time.Duration
+	}{
+		{0, domain.DeliveryStatusPending, time.Minute},
+		// The doubled backoff of 2m is capped at MaxBackoff
+		{time.Minute, domain.DeliveryStatusPending, 90 * time.Second},
+		{90 * time.Second, domain.DeliveryStatusFailed, 0},
+	}
+	for i, step := range steps {
+		wt.now = wt.now.Add(step.wait)
+		wt.process(t)
+
+		d := wt.delivery(t)
+		if d.Attempts != i+1 || d.Status != step.wantStatus {
+			t.Fatalf("attempt %d: attempts, status = %d, %s, want %d, %s",
+				i+1, d.Attempts, d.Status, i+1, step.wantStatus)
+		}
+		if d.ResponseStatus == nil || *d.ResponseStatus != http.StatusInternalServerError {
+			t.Errorf("attempt %d: response status = %v, want 500", i+1, d.ResponseStatus)
+		}
+		if d.LastError == nil || !strings.Contains(*d.LastError, "unexpected status 500: receiver says 500") {
+			t.Errorf("attempt %d: last error = %v", i+1, d.LastError)
+		}
+		if step.wantNext > 0 && !d.NextAttemptAt.Equal(wt.now.Add(step.wantNext)) {
+			t.Errorf("attempt %d: next attempt at %s, want %s", i+1, d.NextAttemptAt, wt.now.Add(step.wantNext))
+		}
+
+		// Nothing is sent again before the backoff has passed
+		wt.process(t)
+		if rc.count() != i+1 {
+			t.Fatalf("attempt %d: receiver got %d requests", i+1, rc.count())
+		}
+	}
+
+	// A failed delivery is never retried
+	wt.now = wt.now.Add(24 * time.Hour)
+	wt.process(t)
+	if rc.count() != len(steps) {
+		t.Errorf("receiver got %d requests after the last attempt, want %d", rc.count(), len(steps))
+	}
+}
+
+func TestWebhookWorkerPurgesDeliveredEvents(t *testing.T) {
+	rc := newReceiver(t, http.StatusOK)
+	wt := newWorkerTest(t, rc, WebhookWorkerConfig{MaxAttempts: 1, Retention: 24 * time.Hour})
+	wt.enqueue(t)
+	wt.process(t)
+
+	// An event whose delivery is still pending is kept however old it is
+	wt.now = wt.now.Add(25 * time.Hour)
+	wt.enqueue(t)
+	if _, err := wt.repo.DispatchPendingEvents(context.Background(), 10, wt.now); err != nil {
+		t.Fatal(err)
+	}
+	wt.now = wt.now.Add(25 * time.Hour)
+	wt.worker.client = &http.Client{Transport: failingTransport{}}
+	wt.worker.cfg.MaxAttempts = 2
+	wt.process(t)
+
+	var events []domain.OutboxEvent
+	if err := wt.db.Order("id").Find(&events).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != 2 {
+		t.Fatalf("kept events %+v, want only the pending event 2", events)
+	}
+
+	d := wt.delivery(t)
+	if d.OutboxEventID != 2 || d.Status != domain.DeliveryStatusPending {
+		t.Errorf("kept delivery = %+v, want the pending delivery of event 2", d)
+	}
+}
+
+// failingTransport fails every request without sending it
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+func TestDefaultClientRefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	client := newClient(WebhookWorkerConfig{Timeout: time.Second})
+	_, err := client.Get(server.URL)
+	if err == nil || !strings.Contains(err.Error(), "refusing to connect to private address 127.0.0.1") {
+		t.Errorf("error = %v, want a refused private address", err)
+	}
+
+	client = newClient(WebhookWorkerConfig{Timeout: time.Second, AllowPrivateURLs: true})
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
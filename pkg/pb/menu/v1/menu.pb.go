@@ -0,0 +1,1388 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: menu/v1/menu.proto
+
+package menuv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MenuEventType int32
+
+const (
+	MenuEventType_MENU_EVENT_TYPE_UNSPECIFIED MenuEventType = 0
+	MenuEventType_MENU_EVENT_TYPE_CREATED     MenuEventType = 1
+	MenuEventType_MENU_EVENT_TYPE_UPDATED     MenuEventType = 2
+	MenuEventType_MENU_EVENT_TYPE_MOVED       MenuEventType = 3
+	MenuEventType_MENU_EVENT_TYPE_DELETED     MenuEventType = 4
+	MenuEventType_MENU_EVENT_TYPE_REORDERED   MenuEventType = 5
+	MenuEventType_MENU_EVENT_TYPE_RESET       MenuEventType = 6
+)
+
+// Enum value maps for MenuEventType.
+var (
+	MenuEventType_name = map[int32]string{
+		0: "MENU_EVENT_TYPE_UNSPECIFIED",
+		1: "MENU_EVENT_TYPE_CREATED",
+		2: "MENU_EVENT_TYPE_UPDATED",
+		3: "MENU_EVENT_TYPE_MOVED",
+		4: "MENU_EVENT_TYPE_DELETED",
+		5: "MENU_EVENT_TYPE_REORDERED",
+		6: "MENU_EVENT_TYPE_RESET",
+	}
+	MenuEventType_value = map[string]int32{
+		"MENU_EVENT_TYPE_UNSPECIFIED": 0,
+		"MENU_EVENT_TYPE_CREATED":     1,
+		"MENU_EVENT_TYPE_UPDATED":     2,
+		"MENU_EVENT_TYPE_MOVED":       3,
+		"MENU_EVENT_TYPE_DELETED":     4,
+		"MENU_EVENT_TYPE_REORDERED":   5,
+		"MENU_EVENT_TYPE_RESET":       6,
+	}
+)
+
+func (x MenuEventType) Enum() *MenuEventType {
+	p := new(MenuEventType)
+	*p = x
+	return p
+}
+
+func (x MenuEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MenuEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_menu_v1_menu_proto_enumTypes[0].Descriptor()
+}
+
+func (MenuEventType) Type() protoreflect.EnumType {
+	return &file_menu_v1_menu_proto_enumTypes[0]
+}
+
+func (x MenuEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MenuEventType.Descriptor instead.
+func (MenuEventType) EnumDescriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{0}
+}
+
+type Menu struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid          string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ParentId      *int64                 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Code          string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Description   *string                `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Route         *string                `protobuf:"bytes,7,opt,name=route,proto3,oneof" json:"route,omitempty"`
+	Icon          *string                `protobuf:"bytes,8,opt,name=icon,proto3,oneof" json:"icon,omitempty"`
+	OrderIndex    int32                  `protobuf:"varint,9,opt,name=order_index,json=orderIndex,proto3" json:"order_index,omitempty"`
+	Level         int32                  `protobuf:"varint,10,opt,name=level,proto3" json:"level,omitempty"`
+	IsActive      bool                   `protobuf:"varint,11,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Children      []*Menu                `protobuf:"bytes,14,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Menu) Reset() {
+	*x = Menu{}
+	mi := &file_menu_v1_menu_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Menu) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Menu) ProtoMessage() {}
+
+func (x *Menu) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Menu.ProtoReflect.Descriptor instead.
+func (*Menu) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Menu) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Menu) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Menu) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *Menu) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Menu) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Menu) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Menu) GetRoute() string {
+	if x != nil && x.Route != nil {
+		return *x.Route
+	}
+	return ""
+}
+
+func (x *Menu) GetIcon() string {
+	if x != nil && x.Icon != nil {
+		return *x.Icon
+	}
+	return ""
+}
+
+func (x *Menu) GetOrderIndex() int32 {
+	if x != nil {
+		return x.OrderIndex
+	}
+	return 0
+}
+
+func (x *Menu) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Menu) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Menu) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Menu) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Menu) GetChildren() []*Menu {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type MenuParentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid          string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Code          string                 `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuParentInfo) Reset() {
+	*x = MenuParentInfo{}
+	mi := &file_menu_v1_menu_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuParentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuParentInfo) ProtoMessage() {}
+
+func (x *MenuParentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuParentInfo.ProtoReflect.Descriptor instead.
+func (*MenuParentInfo) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MenuParentInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuParentInfo) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *MenuParentInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MenuParentInfo) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type MenuDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Menu          *Menu                  `protobuf:"bytes,1,opt,name=menu,proto3" json:"menu,omitempty"`
+	Parent        *MenuParentInfo        `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	Depth         int32                  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuDetail) Reset() {
+	*x = MenuDetail{}
+	mi := &file_menu_v1_menu_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuDetail) ProtoMessage() {}
+
+func (x *MenuDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuDetail.ProtoReflect.Descriptor instead.
+func (*MenuDetail) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MenuDetail) GetMenu() *Menu {
+	if x != nil {
+		return x.Menu
+	}
+	return nil
+}
+
+func (x *MenuDetail) GetParent() *MenuParentInfo {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *MenuDetail) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type CreateMenuRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      *int64                 `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Route         *string                `protobuf:"bytes,5,opt,name=route,proto3,oneof" json:"route,omitempty"`
+	Icon          *string                `protobuf:"bytes,6,opt,name=icon,proto3,oneof" json:"icon,omitempty"`
+	OrderIndex    int32                  `protobuf:"varint,7,opt,name=order_index,json=orderIndex,proto3" json:"order_index,omitempty"`
+	IsActive      bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMenuRequest) Reset() {
+	*x = CreateMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuRequest) ProtoMessage() {}
+
+func (x *CreateMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuRequest.ProtoReflect.Descriptor instead.
+func (*CreateMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateMenuRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateMenuRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetRoute() string {
+	if x != nil && x.Route != nil {
+		return *x.Route
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetIcon() string {
+	if x != nil && x.Icon != nil {
+		return *x.Icon
+	}
+	return ""
+}
+
+func (x *CreateMenuRequest) GetOrderIndex() int32 {
+	if x != nil {
+		return x.OrderIndex
+	}
+	return 0
+}
+
+func (x *CreateMenuRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type UpdateMenuRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId      *int64                 `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Code          string                 `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	Description   *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Route         *string                `protobuf:"bytes,6,opt,name=route,proto3,oneof" json:"route,omitempty"`
+	Icon          *string                `protobuf:"bytes,7,opt,name=icon,proto3,oneof" json:"icon,omitempty"`
+	OrderIndex    int32                  `protobuf:"varint,8,opt,name=order_index,json=orderIndex,proto3" json:"order_index,omitempty"`
+	IsActive      bool                   `protobuf:"varint,9,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMenuRequest) Reset() {
+	*x = UpdateMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuRequest) ProtoMessage() {}
+
+func (x *UpdateMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateMenuRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMenuRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *UpdateMenuRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetRoute() string {
+	if x != nil && x.Route != nil {
+		return *x.Route
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetIcon() string {
+	if x != nil && x.Icon != nil {
+		return *x.Icon
+	}
+	return ""
+}
+
+func (x *UpdateMenuRequest) GetOrderIndex() int32 {
+	if x != nil {
+		return x.OrderIndex
+	}
+	return 0
+}
+
+func (x *UpdateMenuRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type DeleteMenuRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuRequest) Reset() {
+	*x = DeleteMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuRequest) ProtoMessage() {}
+
+func (x *DeleteMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteMenuRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMenuResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuResponse) Reset() {
+	*x = DeleteMenuResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuResponse) ProtoMessage() {}
+
+func (x *DeleteMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMenuResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{6}
+}
+
+type GetMenuRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuRequest) Reset() {
+	*x = GetMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuRequest) ProtoMessage() {}
+
+func (x *GetMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMenuRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetMenuByUUIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuByUUIDRequest) Reset() {
+	*x = GetMenuByUUIDRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuByUUIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuByUUIDRequest) ProtoMessage() {}
+
+func (x *GetMenuByUUIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuByUUIDRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuByUUIDRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMenuByUUIDRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type GetMenuDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuDetailRequest) Reset() {
+	*x = GetMenuDetailRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuDetailRequest) ProtoMessage() {}
+
+func (x *GetMenuDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuDetailRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetMenuDetailRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListMenusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenusRequest) Reset() {
+	*x = ListMenusRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenusRequest) ProtoMessage() {}
+
+func (x *ListMenusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenusRequest.ProtoReflect.Descriptor instead.
+func (*ListMenusRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{10}
+}
+
+type ListRootMenusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRootMenusRequest) Reset() {
+	*x = ListRootMenusRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRootMenusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRootMenusRequest) ProtoMessage() {}
+
+func (x *ListRootMenusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRootMenusRequest.ProtoReflect.Descriptor instead.
+func (*ListRootMenusRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{11}
+}
+
+type ListChildrenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      int64                  `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChildrenRequest) Reset() {
+	*x = ListChildrenRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChildrenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChildrenRequest) ProtoMessage() {}
+
+func (x *ListChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChildrenRequest.ProtoReflect.Descriptor instead.
+func (*ListChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListChildrenRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+type GetMenuHierarchyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RootId        *int64                 `protobuf:"varint,1,opt,name=root_id,json=rootId,proto3,oneof" json:"root_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuHierarchyRequest) Reset() {
+	*x = GetMenuHierarchyRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuHierarchyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuHierarchyRequest) ProtoMessage() {}
+
+func (x *GetMenuHierarchyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuHierarchyRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuHierarchyRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetMenuHierarchyRequest) GetRootId() int64 {
+	if x != nil && x.RootId != nil {
+		return *x.RootId
+	}
+	return 0
+}
+
+type SearchMenusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMenusRequest) Reset() {
+	*x = SearchMenusRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMenusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMenusRequest) ProtoMessage() {}
+
+func (x *SearchMenusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMenusRequest.ProtoReflect.Descriptor instead.
+func (*SearchMenusRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchMenusRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMenusRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListMenusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Menus         []*Menu                `protobuf:"bytes,1,rep,name=menus,proto3" json:"menus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMenusResponse) Reset() {
+	*x = ListMenusResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMenusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMenusResponse) ProtoMessage() {}
+
+func (x *ListMenusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMenusResponse.ProtoReflect.Descriptor instead.
+func (*ListMenusResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMenusResponse) GetMenus() []*Menu {
+	if x != nil {
+		return x.Menus
+	}
+	return nil
+}
+
+type WatchMenusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastVersion   int64                  `protobuf:"varint,1,opt,name=last_version,json=lastVersion,proto3" json:"last_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMenusRequest) Reset() {
+	*x = WatchMenusRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMenusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMenusRequest) ProtoMessage() {}
+
+func (x *WatchMenusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMenusRequest.ProtoReflect.Descriptor instead.
+func (*WatchMenusRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WatchMenusRequest) GetLastVersion() int64 {
+	if x != nil {
+		return x.LastVersion
+	}
+	return 0
+}
+
+type MenuEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Type          MenuEventType          `protobuf:"varint,2,opt,name=type,proto3,enum=menu.v1.MenuEventType" json:"type,omitempty"`
+	MenuId        int64                  `protobuf:"varint,3,opt,name=menu_id,json=menuId,proto3" json:"menu_id,omitempty"`
+	AffectedIds   []int64                `protobuf:"varint,4,rep,packed,name=affected_ids,json=affectedIds,proto3" json:"affected_ids,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuEvent) Reset() {
+	*x = MenuEvent{}
+	mi := &file_menu_v1_menu_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuEvent) ProtoMessage() {}
+
+func (x *MenuEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuEvent.ProtoReflect.Descriptor instead.
+func (*MenuEvent) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MenuEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MenuEvent) GetType() MenuEventType {
+	if x != nil {
+		return x.Type
+	}
+	return MenuEventType_MENU_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *MenuEvent) GetMenuId() int64 {
+	if x != nil {
+		return x.MenuId
+	}
+	return 0
+}
+
+func (x *MenuEvent) GetAffectedIds() []int64 {
+	if x != nil {
+		return x.AffectedIds
+	}
+	return nil
+}
+
+func (x *MenuEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_menu_v1_menu_proto protoreflect.FileDescriptor
+
+const file_menu_v1_menu_proto_rawDesc = "" +
+	"\n" +
+	"\x12menu/v1/menu.proto\x12\amenu.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf5\x03\n" +
+	"\x04Menu\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x03H\x00R\bparentId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05route\x18\a \x01(\tH\x02R\x05route\x88\x01\x01\x12\x17\n" +
+	"\x04icon\x18\b \x01(\tH\x03R\x04icon\x88\x01\x01\x12\x1f\n" +
+	"\vorder_index\x18\t \x01(\x05R\n" +
+	"orderIndex\x12\x14\n" +
+	"\x05level\x18\n" +
+	" \x01(\x05R\x05level\x12\x1b\n" +
+	"\tis_active\x18\v \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12)\n" +
+	"\bchildren\x18\x0e \x03(\v2\r.menu.v1.MenuR\bchildrenB\f\n" +
+	"\n" +
+	"_parent_idB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_routeB\a\n" +
+	"\x05_icon\"\\\n" +
+	"\x0eMenuParentInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\tR\x04code\"v\n" +
+	"\n" +
+	"MenuDetail\x12!\n" +
+	"\x04menu\x18\x01 \x01(\v2\r.menu.v1.MenuR\x04menu\x12/\n" +
+	"\x06parent\x18\x02 \x01(\v2\x17.menu.v1.MenuParentInfoR\x06parent\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\x05R\x05depth\"\xa7\x02\n" +
+	"\x11CreateMenuRequest\x12 \n" +
+	"\tparent_id\x18\x01 \x01(\x03H\x00R\bparentId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05route\x18\x05 \x01(\tH\x02R\x05route\x88\x01\x01\x12\x17\n" +
+	"\x04icon\x18\x06 \x01(\tH\x03R\x04icon\x88\x01\x01\x12\x1f\n" +
+	"\vorder_index\x18\a \x01(\x05R\n" +
+	"orderIndex\x12\x1b\n" +
+	"\tis_active\x18\b \x01(\bR\bisActiveB\f\n" +
+	"\n" +
+	"_parent_idB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_routeB\a\n" +
+	"\x05_icon\"\xb7\x02\n" +
+	"\x11UpdateMenuRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12 \n" +
+	"\tparent_id\x18\x02 \x01(\x03H\x00R\bparentId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\tR\x04code\x12%\n" +
+	"\vdescription\x18\x05 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05route\x18\x06 \x01(\tH\x02R\x05route\x88\x01\x01\x12\x17\n" +
+	"\x04icon\x18\a \x01(\tH\x03R\x04icon\x88\x01\x01\x12\x1f\n" +
+	"\vorder_index\x18\b \x01(\x05R\n" +
+	"orderIndex\x12\x1b\n" +
+	"\tis_active\x18\t \x01(\bR\bisActiveB\f\n" +
+	"\n" +
+	"_parent_idB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_routeB\a\n" +
+	"\x05_icon\"#\n" +
+	"\x11DeleteMenuRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x14\n" +
+	"\x12DeleteMenuResponse\" \n" +
+	"\x0eGetMenuRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"*\n" +
+	"\x14GetMenuByUUIDRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"&\n" +
+	"\x14GetMenuDetailRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x12\n" +
+	"\x10ListMenusRequest\"\x16\n" +
+	"\x14ListRootMenusRequest\"2\n" +
+	"\x13ListChildrenRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\x03R\bparentId\"C\n" +
+	"\x17GetMenuHierarchyRequest\x12\x1c\n" +
+	"\aroot_id\x18\x01 \x01(\x03H\x00R\x06rootId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_root_id\"@\n" +
+	"\x12SearchMenusRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"8\n" +
+	"\x11ListMenusResponse\x12#\n" +
+	"\x05menus\x18\x01 \x03(\v2\r.menu.v1.MenuR\x05menus\"6\n" +
+	"\x11WatchMenusRequest\x12!\n" +
+	"\flast_version\x18\x01 \x01(\x03R\vlastVersion\"\xca\x01\n" +
+	"\tMenuEvent\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.menu.v1.MenuEventTypeR\x04type\x12\x17\n" +
+	"\amenu_id\x18\x03 \x01(\x03R\x06menuId\x12!\n" +
+	"\faffected_ids\x18\x04 \x03(\x03R\vaffectedIds\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*\xdc\x01\n" +
+	"\rMenuEventType\x12\x1f\n" +
+	"\x1bMENU_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17MENU_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17MENU_EVENT_TYPE_UPDATED\x10\x02\x12\x19\n" +
+	"\x15MENU_EVENT_TYPE_MOVED\x10\x03\x12\x1b\n" +
+	"\x17MENU_EVENT_TYPE_DELETED\x10\x04\x12\x1d\n" +
+	"\x19MENU_EVENT_TYPE_REORDERED\x10\x05\x12\x19\n" +
+	"\x15MENU_EVENT_TYPE_RESET\x10\x062\xb1\x06\n" +
+	"\vMenuService\x127\n" +
+	"\n" +
+	"CreateMenu\x12\x1a.menu.v1.CreateMenuRequest\x1a\r.menu.v1.Menu\x127\n" +
+	"\n" +
+	"UpdateMenu\x12\x1a.menu.v1.UpdateMenuRequest\x1a\r.menu.v1.Menu\x12E\n" +
+	"\n" +
+	"DeleteMenu\x12\x1a.menu.v1.DeleteMenuRequest\x1a\x1b.menu.v1.DeleteMenuResponse\x121\n" +
+	"\aGetMenu\x12\x17.menu.v1.GetMenuRequest\x1a\r.menu.v1.Menu\x12=\n" +
+	"\rGetMenuByUUID\x12\x1d.menu.v1.GetMenuByUUIDRequest\x1a\r.menu.v1.Menu\x12C\n" +
+	"\rGetMenuDetail\x12\x1d.menu.v1.GetMenuDetailRequest\x1a\x13.menu.v1.MenuDetail\x12B\n" +
+	"\tListMenus\x12\x19.menu.v1.ListMenusRequest\x1a\x1a.menu.v1.ListMenusResponse\x12J\n" +
+	"\rListRootMenus\x12\x1d.menu.v1.ListRootMenusRequest\x1a\x1a.menu.v1.ListMenusResponse\x12H\n" +
+	"\fListChildren\x12\x1c.menu.v1.ListChildrenRequest\x1a\x1a.menu.v1.ListMenusResponse\x12P\n" +
+	"\x10GetMenuHierarchy\x12 .menu.v1.GetMenuHierarchyRequest\x1a\x1a.menu.v1.ListMenusResponse\x12F\n" +
+	"\vSearchMenus\x12\x1b.menu.v1.SearchMenusRequest\x1a\x1a.menu.v1.ListMenusResponse\x12>\n" +
+	"\n" +
+	"WatchMenus\x12\x1a.menu.v1.WatchMenusRequest\x1a\x12.menu.v1.MenuEvent0\x01B.Z,stk-technical-test-api/pkg/pb/menu/v1;menuv1b\x06proto3"
+
+var (
+	file_menu_v1_menu_proto_rawDescOnce sync.Once
+	file_menu_v1_menu_proto_rawDescData []byte
+)
+
+func file_menu_v1_menu_proto_rawDescGZIP() []byte {
+	file_menu_v1_menu_proto_rawDescOnce.Do(func() {
+		file_menu_v1_menu_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_menu_v1_menu_proto_rawDesc), len(file_menu_v1_menu_proto_rawDesc)))
+	})
+	return file_menu_v1_menu_proto_rawDescData
+}
+
+var file_menu_v1_menu_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_menu_v1_menu_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_menu_v1_menu_proto_goTypes = []any{
+	(MenuEventType)(0),              // 0: menu.v1.MenuEventType
+	(*Menu)(nil),                    // 1: menu.v1.Menu
+	(*MenuParentInfo)(nil),          // 2: menu.v1.MenuParentInfo
+	(*MenuDetail)(nil),              // 3: menu.v1.MenuDetail
+	(*CreateMenuRequest)(nil),       // 4: menu.v1.CreateMenuRequest
+	(*UpdateMenuRequest)(nil),       // 5: menu.v1.UpdateMenuRequest
+	(*DeleteMenuRequest)(nil),       // 6: menu.v1.DeleteMenuRequest
+	(*DeleteMenuResponse)(nil),      // 7: menu.v1.DeleteMenuResponse
+	(*GetMenuRequest)(nil),          // 8: menu.v1.GetMenuRequest
+	(*GetMenuByUUIDRequest)(nil),    // 9: menu.v1.GetMenuByUUIDRequest
+	(*GetMenuDetailRequest)(nil),    // 10: menu.v1.GetMenuDetailRequest
+	(*ListMenusRequest)(nil),        // 11: menu.v1.ListMenusRequest
+	(*ListRootMenusRequest)(nil),    // 12: menu.v1.ListRootMenusRequest
+	(*ListChildrenRequest)(nil),     // 13: menu.v1.ListChildrenRequest
+	(*GetMenuHierarchyRequest)(nil), // 14: menu.v1.GetMenuHierarchyRequest
+	(*SearchMenusRequest)(nil),      // 15: menu.v1.SearchMenusRequest
+	(*ListMenusResponse)(nil),       // 16: menu.v1.ListMenusResponse
+	(*WatchMenusRequest)(nil),       // 17: menu.v1.WatchMenusRequest
+	(*MenuEvent)(nil),               // 18: menu.v1.MenuEvent
+	(*timestamppb.Timestamp)(nil),   // 19: google.protobuf.Timestamp
+}
+var file_menu_v1_menu_proto_depIdxs = []int32{
+	19, // 0: menu.v1.Menu.created_at:type_name -> google.protobuf.Timestamp
+	19, // 1: menu.v1.Menu.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: menu.v1.Menu.children:type_name -> menu.v1.Menu
+	1,  // 3: menu.v1.MenuDetail.menu:type_name -> menu.v1.Menu
+	2,  // 4: menu.v1.MenuDetail.parent:type_name -> menu.v1.MenuParentInfo
+	1,  // 5: menu.v1.ListMenusResponse.menus:type_name -> menu.v1.Menu
+	0,  // 6: menu.v1.MenuEvent.type:type_name -> menu.v1.MenuEventType
+	19, // 7: menu.v1.MenuEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	4,  // 8: menu.v1.MenuService.CreateMenu:input_type -> menu.v1.CreateMenuRequest
+	5,  // 9: menu.v1.MenuService.UpdateMenu:input_type -> menu.v1.UpdateMenuRequest
+	6,  // 10: menu.v1.MenuService.DeleteMenu:input_type -> menu.v1.DeleteMenuRequest
+	8,  // 11: menu.v1.MenuService.GetMenu:input_type -> menu.v1.GetMenuRequest
+	9,  // 12: menu.v1.MenuService.GetMenuByUUID:input_type -> menu.v1.GetMenuByUUIDRequest
+	10, // 13: menu.v1.MenuService.GetMenuDetail:input_type -> menu.v1.GetMenuDetailRequest
+	11, // 14: menu.v1.MenuService.ListMenus:input_type -> menu.v1.ListMenusRequest
+	12, // 15: menu.v1.MenuService.ListRootMenus:input_type -> menu.v1.ListRootMenusRequest
+	13, // 16: menu.v1.MenuService.ListChildren:input_type -> menu.v1.ListChildrenRequest
+	14, // 17: menu.v1.MenuService.GetMenuHierarchy:input_type -> menu.v1.GetMenuHierarchyRequest
+	15, // 18: menu.v1.MenuService.SearchMenus:input_type -> menu.v1.SearchMenusRequest
+	17, // 19: menu.v1.MenuService.WatchMenus:input_type -> menu.v1.WatchMenusRequest
+	1,  // 20: menu.v1.MenuService.CreateMenu:output_type -> menu.v1.Menu
+	1,  // 21: menu.v1.MenuService.UpdateMenu:output_type -> menu.v1.Menu
+	7,  // 22: menu.v1.MenuService.DeleteMenu:output_type -> menu.v1.DeleteMenuResponse
+	1,  // 23: menu.v1.MenuService.GetMenu:output_type -> menu.v1.Menu
+	1,  // 24: menu.v1.MenuService.GetMenuByUUID:output_type -> menu.v1.Menu
+	3,  // 25: menu.v1.MenuService.GetMenuDetail:output_type -> menu.v1.MenuDetail
+	16, // 26: menu.v1.MenuService.ListMenus:output_type -> menu.v1.ListMenusResponse
+	16, // 27: menu.v1.MenuService.ListRootMenus:output_type -> menu.v1.ListMenusResponse
+	16, // 28: menu.v1.MenuService.ListChildren:output_type -> menu.v1.ListMenusResponse
+	16, // 29: menu.v1.MenuService.GetMenuHierarchy:output_type -> menu.v1.ListMenusResponse
+	16, // 30: menu.v1.MenuService.SearchMenus:output_type -> menu.v1.ListMenusResponse
+	18, // 31: menu.v1.MenuService.WatchMenus:output_type -> menu.v1.MenuEvent
+	20, // [20:32] is the sub-list for method output_type
+	8,  // [8:20] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_menu_v1_menu_proto_init() }
+func file_menu_v1_menu_proto_init() {
+	if File_menu_v1_menu_proto != nil {
+		return
+	}
+	file_menu_v1_menu_proto_msgTypes[0].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[3].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[4].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_menu_v1_menu_proto_rawDesc), len(file_menu_v1_menu_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_menu_v1_menu_proto_goTypes,
+		DependencyIndexes: file_menu_v1_menu_proto_depIdxs,
+		EnumInfos:         file_menu_v1_menu_proto_enumTypes,
+		MessageInfos:      file_menu_v1_menu_proto_msgTypes,
+	}.Build()
+	File_menu_v1_menu_proto = out.File
+	file_menu_v1_menu_proto_goTypes = nil
+	file_menu_v1_menu_proto_depIdxs = nil
+}
@@ -1,27 +1,76 @@
 package response
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Error codes returned in the code field of failed responses. They are part
+// of the API contract and must not change once published.
+const (
+	CodeInvalidRequest           = "invalid_request"
+	CodeNotFound                 = "not_found"
+	CodeConflict                 = "conflict"
+	CodeHasChildren              = "has_children"
+	CodeCycle                    = "cycle"
+	CodeValidationFailed         = "validation_failed"
+	CodeTimeout                  = "timeout"
+	CodeClientClosedRequest      = "client_closed_request"
+	CodePayloadTooLarge          = "payload_too_large"
+	CodeRateLimited              = "rate_limited"
+	CodeIdempotencyKeyReused     = "idempotency_key_reused"
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+	CodeInternalError            = "internal_error"
+)
+
+// RequestIDKey is the Gin context key under which the request ID middleware
+// stores the ID copied into every response
+const RequestIDKey = "request_id"
 
 type Response struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   interface{} `json:"error,omitempty"`
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Code       string      `json:"code,omitempty"`
+	Data       interface{} `json:"data,omitempty"`
+	Error      interface{} `json:"error,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+	RequestID  string      `json:"request_id,omitempty"`
+}
+
+// Violation describes why a single request field is invalid
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func Success(c *gin.Context, statusCode int, message string, data interface{}) {
 	c.JSON(statusCode, Response{
-		Success: true,
-		Message: message,
-		Data:    data,
+		Success:   true,
+		Message:   message,
+		Data:      data,
+		RequestID: c.GetString(RequestIDKey),
 	})
 }
 
-func Error(c *gin.Context, statusCode int, message string, err interface{}) {
+func Error(c *gin.Context, statusCode int, code string, message string, err interface{}) {
 	c.JSON(statusCode, Response{
-		Success: false,
-		Message: message,
-		Error:   err,
+		Success:   false,
+		Message:   message,
+		Code:      code,
+		Error:     err,
+		RequestID: c.GetString(RequestIDKey),
 	})
 }
 
+// Invalid responds with 422 and the list of invalid fields
+func Invalid(c *gin.Context, message string, err interface{}, violations []Violation) {
+	c.JSON(http.StatusUnprocessableEntity, Response{
+		Success:    false,
+		Message:    message,
+		Code:       CodeValidationFailed,
+		Error:      err,
+		Violations: violations,
+		RequestID:  c.GetString(RequestIDKey),
+	})
+}